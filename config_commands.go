@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/clockify"
+	"github.com/kruc/clockify-to-jira/internal/config"
+	"github.com/kruc/clockify-to-jira/internal/outcome"
+	"github.com/kruc/clockify-to-jira/internal/wizard"
+)
+
+func validateConfig(log *slog.Logger, configFilePath string) int {
+
+	problems, err := config.ValidateFile(configFilePath)
+
+	if err != nil {
+		log.Error("Ops, something went wrong while loading the configuration!",
+			"error", err)
+		return outcome.ExitConfigError
+	}
+
+	for _, problem := range problems {
+		log.Error("Invalid configuration",
+			"file", problem.File,
+			"path", problem.Path,
+			"line", problem.Line,
+			"problem", problem.Message,
+		)
+	}
+
+	if len(problems) == 0 {
+		log.Info("Configuration is valid", "file", configFilePath)
+		return outcome.ExitOk
+	}
+
+	return outcome.ExitConfigError
+}
+
+func initializeConfig(log *slog.Logger, configFilePath string, interactive bool) int {
+
+	if config.FileExists(configFilePath) {
+		log.Error("Ops, something went wrong during config initialization!",
+			"error", config.ErrGeneratorConfigFileAlreadyExists,
+			"file", configFilePath)
+		return outcome.ExitConfigError
+	}
+
+	if !interactive {
+		err := config.InitializeConfig(configFilePath)
+
+		if err != nil {
+			log.Error("Ops, something went wrong during config initialization!",
+				"error", err)
+			return outcome.ExitConfigError
+		}
+
+		log.Info("Configuration template created - adjust it to your needs", "file", configFilePath)
+		return outcome.ExitOk
+	}
+
+	configWizard := wizard.New(os.Stdin, os.Stdout, func(clockifyToken string) (wizard.Directory, error) {
+		return clockify.NewClient(clockifyToken)
+	})
+
+	generatedConfig, err := configWizard.Run()
+
+	if err != nil {
+		log.Error("Ops, something went wrong during config wizard!",
+			"error", err)
+		return outcome.ExitConfigError
+	}
+
+	err = config.SaveConfig(configFilePath, generatedConfig)
+
+	if err != nil {
+		log.Error("Ops, something went wrong during config initialization!",
+			"error", err)
+		return outcome.ExitConfigError
+	}
+
+	log.Info("Configuration created", "file", configFilePath)
+
+	return outcome.ExitOk
+}
+
+func migrateConfig(log *slog.Logger, configFilePath string, write bool) int {
+
+	if !write {
+		migratedYaml, sourceVersion, err := config.MigrateFile(configFilePath)
+
+		if err != nil {
+			log.Error("Ops, something went wrong during config migration!",
+				"error", err)
+			return outcome.ExitConfigError
+		}
+
+		log.Info("Migrated configuration (dry run - use --write to rewrite the file)",
+			"version", sourceVersion)
+		fmt.Print(string(migratedYaml))
+		return outcome.ExitOk
+	}
+
+	backupPath, err := config.MigrateYamlFile(configFilePath, time.Now())
+
+	if err != nil {
+		log.Error("Ops, something went wrong during config migration!",
+			"error", err)
+		return outcome.ExitConfigError
+	}
+
+	if backupPath == "" {
+		log.Info("Configuration already uses the current layout", "file", configFilePath)
+		return outcome.ExitOk
+	}
+
+	log.Info("Configuration migrated", "file", configFilePath, "backup", backupPath)
+
+	return outcome.ExitOk
+}
+
+func showConfig(log *slog.Logger, configFilePath string) int {
+
+	output, err := config.ShowFile(configFilePath)
+
+	if err != nil {
+		log.Error("Ops, something went wrong while loading the configuration!",
+			"error", err)
+		return outcome.ExitConfigError
+	}
+
+	fmt.Print(output)
+
+	return outcome.ExitOk
+}
+
+func showConfigSchema(log *slog.Logger) int {
+
+	schema, err := config.Schema()
+
+	if err != nil {
+		log.Error("Ops, something went wrong while generating the configuration schema!",
+			"error", err)
+		return outcome.ExitFailure
+	}
+
+	fmt.Println(string(schema))
+
+	return outcome.ExitOk
+}
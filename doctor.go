@@ -0,0 +1,161 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/kruc/clockify-to-jira/internal/clockify"
+	"github.com/kruc/clockify-to-jira/internal/config"
+	"github.com/kruc/clockify-to-jira/internal/doctor"
+	"github.com/kruc/clockify-to-jira/internal/jira"
+)
+
+// getDoctorReport checks clockify token, selected workspaces with their migration tags and jira access of enabled clients
+func (m *migration) getDoctorReport(workspaces config.Workspaces) *doctor.Report {
+
+	report := &doctor.Report{}
+
+	if m.checkClockifyToken(report) {
+		m.checkWorkspaces(report, workspaces)
+	} else {
+		for _, workspaceKey := range sortedWorkspaceKeys(workspaces) {
+			report.Skip(workspaceKey, "workspace", doctor.SkipReason("clockify token"))
+		}
+	}
+
+	for _, workspaceKey := range sortedWorkspaceKeys(workspaces) {
+		workspace := workspaces[workspaceKey]
+
+		for _, clientId := range slices.Sorted(maps.Keys(workspace.Clients)) {
+			client := workspace.Clients[clientId]
+
+			if !client.Enabled || (len(m.flag.Clients) != 0 && !slices.Contains(m.flag.Clients, clientId)) {
+				continue
+			}
+
+			checkJiraClient(report, workspaceKey+"/"+clientId, client)
+		}
+	}
+
+	return report
+}
+
+func (m *migration) checkClockifyToken(report *doctor.Report) bool {
+
+	if m.clockifyClient == nil {
+		return report.Add("clockify", "token", clockify.ErrClockifyClientInitError, "")
+	}
+
+	user, err := m.clockifyClient.GetLoggedInUser()
+
+	if err != nil {
+		return report.Add("clockify", "token", err, "")
+	}
+
+	return report.Add("clockify", "token", nil, user.Email)
+}
+
+func (m *migration) checkWorkspaces(report *doctor.Report, workspaces config.Workspaces) {
+
+	clockifyWorkspaces, err := m.clockifyClient.GetWorkspaces()
+
+	if !report.Add("clockify", "workspaces", err, fmt.Sprintf("%d available", len(clockifyWorkspaces))) {
+		return
+	}
+
+	for _, workspaceKey := range sortedWorkspaceKeys(workspaces) {
+		workspace := workspaces[workspaceKey]
+
+		found := slices.ContainsFunc(clockifyWorkspaces, func(clockifyWorkspace clockify.Workspace) bool {
+			return clockifyWorkspace.ID == workspace.WorkspaceId
+		})
+
+		if !found {
+			report.Add(workspaceKey, "workspace", fmt.Errorf("workspace %q not found - check workspace_id", workspace.WorkspaceId), "")
+			report.Skip(workspaceKey, "migration tags", doctor.SkipReason("workspace"))
+			continue
+		}
+
+		report.Add(workspaceKey, "workspace", nil, workspace.WorkspaceId)
+
+		clockifyTags, err := m.clockifyClient.GetWorkspaceTags(workspace.WorkspaceId)
+
+		if !report.Add(workspaceKey, "migration tags", err, "") {
+			continue
+		}
+
+		for _, tagName := range migrationTags(workspace) {
+			_, ok := clockifyTags[tagName]
+
+			if ok {
+				report.Add(workspaceKey, "tag "+tagName, nil, "")
+			} else {
+				report.Add(workspaceKey, "tag "+tagName, fmt.Errorf("tag %q not found - create it in clockify", tagName), "")
+			}
+		}
+	}
+}
+
+// checkJiraClient checks jira host, authentication and worklog permission, every check runs only when the previous one passed
+func checkJiraClient(report *doctor.Report, scope string, client *config.Client) {
+
+	jiraClient, err := jira.NewClient(client.JiraHost, client.JiraUsername, client.JiraPassword, client.JiraApiVersion)
+
+	if err == nil {
+		err = jiraClient.Ping()
+	}
+
+	if !report.Add(scope, "jira host", err, client.JiraHost) {
+		report.Skip(scope, "authentication", doctor.SkipReason("jira host"))
+		return
+	}
+
+	user, err := jiraClient.Myself()
+
+	if !report.Add(scope, "authentication", err, user) {
+		report.Skip(scope, "worklog permission", doctor.SkipReason("authentication"))
+		return
+	}
+
+	if len(client.JiraProjects) == 0 {
+		checkWorklogPermission(report, scope, "worklog permission", jiraClient, "")
+		return
+	}
+
+	for _, projectKey := range client.JiraProjects {
+		checkWorklogPermission(report, scope, "worklog permission "+projectKey, jiraClient, projectKey)
+	}
+}
+
+// checkWorklogPermission checks permission to log work in the project, empty project key checks any project
+func checkWorklogPermission(report *doctor.Report, scope, name string, jiraClient *jira.Client, projectKey string) {
+
+	allowed, err := jiraClient.CanLogWork(projectKey)
+
+	if err == nil && !allowed {
+		err = errors.New("missing Work On Issues permission")
+	}
+
+	detail := ""
+
+	if projectKey == "" {
+		detail = "any project"
+	}
+
+	report.Add(scope, name, err, detail)
+}
+
+func migrationTags(workspace *config.Workspace) []string {
+
+	tags := []string{}
+
+	for _, tagName := range []string{workspace.JiraMigrationSuccessTag, workspace.JiraMigrationFailedTag, workspace.JiraMigrationSkipTag} {
+		if tagName != "" && !slices.Contains(tags, tagName) {
+			tags = append(tags, tagName)
+		}
+	}
+
+	return tags
+}
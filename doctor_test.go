@@ -0,0 +1,77 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kruc/clockify-to-jira/internal/config"
+	"github.com/kruc/clockify-to-jira/internal/doctor"
+)
+
+func Test_getDoctorReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, _, _ := r.BasicAuth()
+
+		switch {
+		case r.URL.Path == "/rest/api/2/serverInfo":
+			w.Write([]byte(`{"version":"9.12.0"}`))
+		case username != "user" && username != "readonly":
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/rest/api/2/myself":
+			w.Write([]byte(`{"displayName":"John Doe"}`))
+		case username == "readonly" || r.URL.Query().Get("projectKey") == "OPS":
+			w.Write([]byte(`{"permissions":{"WORK_ON_ISSUES":{"havePermission":false}}}`))
+		default:
+			w.Write([]byte(`{"permissions":{"WORK_ON_ISSUES":{"havePermission":true}}}`))
+		}
+	}))
+	defer server.Close()
+
+	workspaces := config.Workspaces{
+		"ws_1": &config.Workspace{
+			WorkspaceId: "ws-1",
+			Clients: config.Clients{
+				"acme":     {Enabled: true, JiraHost: server.URL, JiraUsername: "user", JiraProjects: []string{"ABC", "OPS"}},
+				"readonly": {Enabled: true, JiraHost: server.URL, JiraUsername: "readonly"},
+				"expired":  {Enabled: true, JiraHost: server.URL, JiraUsername: "expired"},
+				"disabled": {Enabled: false, JiraHost: server.URL},
+			},
+		},
+	}
+
+	m := &migration{log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	report := m.getDoctorReport(workspaces)
+
+	want := []doctor.Check{
+		{Scope: "clockify", Name: "token", Status: doctor.StatusFailed, Detail: "Clockify client init error - check your token"},
+		{Scope: "ws_1", Name: "workspace", Status: doctor.StatusSkipped, Detail: "clockify token check failed"},
+		{Scope: "ws_1/acme", Name: "jira host", Status: doctor.StatusOk, Detail: server.URL},
+		{Scope: "ws_1/acme", Name: "authentication", Status: doctor.StatusOk, Detail: "John Doe"},
+		{Scope: "ws_1/acme", Name: "worklog permission ABC", Status: doctor.StatusOk},
+		{Scope: "ws_1/acme", Name: "worklog permission OPS", Status: doctor.StatusFailed, Detail: "missing Work On Issues permission"},
+		{Scope: "ws_1/expired", Name: "jira host", Status: doctor.StatusOk, Detail: server.URL},
+		{Scope: "ws_1/expired", Name: "authentication", Status: doctor.StatusFailed},
+		{Scope: "ws_1/expired", Name: "worklog permission", Status: doctor.StatusSkipped, Detail: "authentication check failed"},
+		{Scope: "ws_1/readonly", Name: "jira host", Status: doctor.StatusOk, Detail: server.URL},
+		{Scope: "ws_1/readonly", Name: "authentication", Status: doctor.StatusOk, Detail: "John Doe"},
+		{Scope: "ws_1/readonly", Name: "worklog permission", Status: doctor.StatusFailed, Detail: "missing Work On Issues permission"},
+	}
+
+	if len(report.Checks) != len(want) {
+		t.Fatalf("getDoctorReport() returned %d checks, want %d: %v", len(report.Checks), len(want), report.Checks)
+	}
+
+	for index, check := range report.Checks {
+		// authentication error detail contains jira response
+		if want[index].Name == "authentication" && want[index].Status == doctor.StatusFailed {
+			check.Detail = ""
+		}
+
+		if check != want[index] {
+			t.Errorf("getDoctorReport() check %d = %v, want %v", index, check, want[index])
+		}
+	}
+}
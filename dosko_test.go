@@ -2,6 +2,11 @@ package main
 
 import (
 	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+	"github.com/kruc/clockify-to-jira/internal/config"
+	"github.com/kruc/clockify-to-jira/internal/rounding"
 )
 
 func Test_dosko(t *testing.T) {
@@ -69,7 +74,11 @@ func Test_dosko(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got1, got2, got3 := dosko(tt.args.timeSpentSeconds, tt.args.stachurskyMode)
+			roundingOptions := rounding.Options{Precision: time.Duration(tt.args.stachurskyMode) * time.Minute}
+			got1, got2, got3, err := dosko(tt.args.timeSpentSeconds, roundingOptions)
+			if err != nil {
+				t.Errorf("dosko() error = %v", err)
+			}
 			if got1 != tt.want1 {
 				t.Errorf("dosko() got = %d, want %d", got1, tt.want1)
 			}
@@ -82,3 +91,43 @@ func Test_dosko(t *testing.T) {
 		})
 	}
 }
+
+func Test_doskoError(t *testing.T) {
+	_, _, _, err := dosko(960, rounding.Options{})
+
+	assert.Errors(t, err, rounding.ErrRoundingInvalidPrecision)
+}
+
+func Test_compareRoundingStrategies(t *testing.T) {
+	got, err := compareRoundingStrategies(1320, rounding.Options{Precision: 15 * time.Minute, Grace: 5 * time.Minute}) // 22min
+
+	assert.Errors(t, err, nil)
+	assert.Ints(t, got[rounding.Nearest], 900)
+	assert.Ints(t, got[rounding.Ceil], 1800)
+	assert.Ints(t, got[rounding.Floor], 900)
+	assert.Ints(t, got[rounding.Grace], 1800)
+}
+
+func Test_getRoundingOptions(t *testing.T) {
+
+	t.Run("Use nearest strategy by default", func(t *testing.T) {
+		got := getRoundingOptions(&config.Client{StachurskyMode: 15})
+
+		assert.Strings(t, got.Strategy, rounding.Nearest)
+		assert.Strings(t, got.Precision.String(), "15m0s")
+	})
+
+	t.Run("Convert client rounding config", func(t *testing.T) {
+		got := getRoundingOptions(&config.Client{
+			StachurskyMode:   30,
+			RoundingStrategy: rounding.Grace,
+			RoundingGrace:    5,
+			RoundingMinimum:  60,
+		})
+
+		assert.Strings(t, got.Strategy, rounding.Grace)
+		assert.Strings(t, got.Precision.String(), "30m0s")
+		assert.Strings(t, got.Grace.String(), "5m0s")
+		assert.Strings(t, got.Minimum.String(), "1h0m0s")
+	})
+}
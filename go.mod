@@ -3,10 +3,14 @@ module github.com/kruc/clockify-to-jira
 go 1.23
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/andygrunwald/go-jira v1.12.0
 	github.com/lucassabreu/clockify-cli v0.54.0
 	github.com/spf13/pflag v1.0.5
+	golang.org/x/term v0.25.0
+	golang.org/x/text v0.16.0
 	gopkg.in/yaml.v2 v2.2.4
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -17,5 +21,5 @@ require (
 	github.com/kr/text v0.2.0 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/trivago/tgo v1.0.1 // indirect
-	golang.org/x/text v0.16.0 // indirect
+	golang.org/x/sys v0.26.0 // indirect
 )
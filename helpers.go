@@ -1,26 +1,65 @@
 package main
 
 import (
-	"fmt"
+	"regexp"
 	s "strings"
 	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/config"
+	"github.com/kruc/clockify-to-jira/internal/rounding"
+)
+
+var (
+	jiraBrowseURLPattern = regexp.MustCompile(`^(https?://[^/\s]+)(?:/[^\s]*)?/browse/([A-Z][A-Z0-9]+-[0-9]+)`)
 )
 
-func dosko(timeSpentSeconds, stachurskyMode int) (int, string, string) {
+func dosko(timeSpentSeconds int, roundingOptions rounding.Options) (int, string, string, error) {
+
+	timeSpent := time.Duration(timeSpentSeconds) * time.Second
+	roundedValue, err := rounding.Round(timeSpent, roundingOptions)
+
+	if err != nil {
+		return 0, "", "", err
+	}
+
+	return int(roundedValue.Seconds()), timeSpent.String(), roundedValue.String(), nil
+}
+
+func compareRoundingStrategies(timeSpentSeconds int, roundingOptions rounding.Options) (map[string]int, error) {
+
+	roundedValues, err := rounding.RoundAll(time.Duration(timeSpentSeconds)*time.Second, roundingOptions)
 
-	d, err := time.ParseDuration(fmt.Sprintf("%vs", timeSpentSeconds))
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	stachurskyFactor := time.Duration(stachurskyMode) * time.Minute
-	roundedValue := d.Round(stachurskyFactor)
+	result := make(map[string]int, len(roundedValues))
 
-	if int(roundedValue.Seconds()) == 0 {
-		roundedValue = stachurskyFactor
+	for strategy, roundedValue := range roundedValues {
+		result[strategy] = int(roundedValue.Seconds())
 	}
 
-	return int(roundedValue.Seconds()), d.String(), roundedValue.String()
+	return result, nil
+}
+
+func getRoundingOptions(clientConfig *config.Client) rounding.Options {
+
+	strategy := clientConfig.RoundingStrategy
+
+	if strategy == "" {
+		strategy = rounding.Nearest
+	}
+
+	return rounding.Options{
+		Strategy:  strategy,
+		Precision: time.Duration(clientConfig.StachurskyMode) * time.Minute,
+		Grace:     time.Duration(clientConfig.RoundingGrace) * time.Minute,
+		Minimum:   time.Duration(clientConfig.RoundingMinimum) * time.Minute,
+	}
+}
+
+func formatSeconds(seconds int) string {
+	return (time.Duration(seconds) * time.Second).String()
 }
 
 func adjustClockifyDate(clockifyDate time.Time) time.Time {
@@ -32,9 +71,51 @@ func adjustClockifyDate(clockifyDate time.Time) time.Time {
 func parseIssueID(value string) string {
 	fields := s.Fields(value)
 
+	if _, issueID, ok := parseIssueURL(value); ok {
+		return issueID
+	}
+
 	return trimBrackets(fields[0])
 }
 
+func parseIssueURL(value string) (string, string, bool) {
+	fields := s.Fields(value)
+
+	if len(fields) == 0 {
+		return "", "", false
+	}
+
+	matches := jiraBrowseURLPattern.FindStringSubmatch(trimBrackets(fields[0]))
+
+	if matches == nil {
+		return "", "", false
+	}
+
+	return matches[1], matches[2], true
+}
+
+// matchesIssue checks whether time entry description resolves to one of given issue ids, empty list matches all
+func matchesIssue(value string, issueIDs []string) bool {
+
+	if len(issueIDs) == 0 {
+		return true
+	}
+
+	if len(s.Fields(value)) == 0 {
+		return false
+	}
+
+	issueID := parseIssueID(value)
+
+	for _, selectedIssueID := range issueIDs {
+		if s.EqualFold(selectedIssueID, issueID) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func trimBrackets(issueID string) string {
 	trimmedissueID := s.TrimPrefix(issueID, "[")
 	trimmedissueID = s.TrimSuffix(trimmedissueID, ":")
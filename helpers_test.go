@@ -61,6 +61,16 @@ func Test_parseIssueID(t *testing.T) {
 			args: args{"[ID-123]: Some description"},
 			want: "ID-123",
 		},
+		{
+			name: "Parse issue id from browse url",
+			args: args{"https://acme.atlassian.net/browse/ABC-12 fix login"},
+			want: "ABC-12",
+		},
+		{
+			name: "Parse issue id from browse url with query",
+			args: args{"https://jira.acme.com/jira/browse/ABC-12?focusedCommentId=1: fix login"},
+			want: "ABC-12",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -71,6 +81,51 @@ func Test_parseIssueID(t *testing.T) {
 	}
 }
 
+func Test_matchesIssue(t *testing.T) {
+	type args struct {
+		value    string
+		issueIDs []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			name: "Match all without issue ids",
+			args: args{"ID-123 Some description", []string{}},
+			want: true,
+		},
+		{
+			name: "Match issue id ignoring case",
+			args: args{"[ID-123] Some description", []string{"abc-1", "id-123"}},
+			want: true,
+		},
+		{
+			name: "Match issue id from browse url",
+			args: args{"https://acme.atlassian.net/browse/ABC-12 fix login", []string{"ABC-12"}},
+			want: true,
+		},
+		{
+			name: "Don't match other issue id",
+			args: args{"ID-1234 Some description", []string{"ID-123"}},
+			want: false,
+		},
+		{
+			name: "Don't match empty description",
+			args: args{"", []string{"ID-123"}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesIssue(tt.args.value, tt.args.issueIDs); got != tt.want {
+				t.Errorf("matchesIssue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_parseIssueComment(t *testing.T) {
 	type args struct {
 		value string
@@ -95,6 +150,11 @@ func Test_parseIssueComment(t *testing.T) {
 			args: args{"ID-123 Some description 3"},
 			want: "Some description 3",
 		},
+		{
+			name: "Parse comment after browse url",
+			args: args{"https://acme.atlassian.net/browse/ABC-12 fix login"},
+			want: "fix login",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -105,6 +165,51 @@ func Test_parseIssueComment(t *testing.T) {
 	}
 }
 
+func Test_parseIssueURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		wantHost    string
+		wantIssueID string
+		wantOk      bool
+	}{
+		{
+			name:        "Parse cloud browse url",
+			value:       "https://acme.atlassian.net/browse/ABC-12 fix login",
+			wantHost:    "https://acme.atlassian.net",
+			wantIssueID: "ABC-12",
+			wantOk:      true,
+		},
+		{
+			name:        "Parse server browse url with context path",
+			value:       "[http://jira.acme.com/jira/browse/XYZ-1]: fix login",
+			wantHost:    "http://jira.acme.com",
+			wantIssueID: "XYZ-1",
+			wantOk:      true,
+		},
+		{
+			name:  "Ignore plain issue id",
+			value: "ABC-12 fix login",
+		},
+		{
+			name:  "Ignore url without issue",
+			value: "https://acme.atlassian.net/jira/dashboards fix login",
+		},
+		{
+			name:  "Ignore empty description",
+			value: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, issueID, ok := parseIssueURL(tt.value)
+			if host != tt.wantHost || issueID != tt.wantIssueID || ok != tt.wantOk {
+				t.Errorf("parseIssueURL() = %v, %v, %v, want %v, %v, %v", host, issueID, ok, tt.wantHost, tt.wantIssueID, tt.wantOk)
+			}
+		})
+	}
+}
+
 func Test_getTimeDiff(t *testing.T) {
 	type args struct {
 		start time.Time
@@ -132,3 +237,21 @@ func Test_getTimeDiff(t *testing.T) {
 		})
 	}
 }
+
+func Test_formatSeconds(t *testing.T) {
+	tests := []struct {
+		name    string
+		seconds int
+		want    string
+	}{
+		{name: "Format seconds", seconds: 45, want: "45s"},
+		{name: "Format hours", seconds: 5400, want: "1h30m0s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatSeconds(tt.seconds); got != tt.want {
+				t.Errorf("formatSeconds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
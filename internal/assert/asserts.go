@@ -1,6 +1,7 @@
 package assert
 
 import (
+	"errors"
 	"os"
 	"reflect"
 	"slices"
@@ -50,6 +51,14 @@ func Errors(t testing.TB, got, want error) {
 	}
 }
 
+func ErrorsIs(t testing.TB, got, want error) {
+	t.Helper()
+
+	if !errors.Is(got, want) {
+		t.Errorf("got %q want %q", got, want)
+	}
+}
+
 func Nils(t testing.TB, got interface{}) {
 	t.Helper()
 
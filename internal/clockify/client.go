@@ -1,6 +1,10 @@
 package clockify
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
 	"time"
 
 	"github.com/lucassabreu/clockify-cli/api"
@@ -12,6 +16,8 @@ const (
 	ErrClockifyFailToFetchLoggedInUserData = ClockifyErr("Cannot get logged in user data")
 	ErrClockifyFailToFetchTimeEntries      = ClockifyErr("Cannot fetch timeentries")
 	ErrClockifyFailToFetchWorkspaceTags    = ClockifyErr("Cannot fetch workspace tags")
+	ErrClockifyFailToFetchWorkspaces       = ClockifyErr("Cannot fetch workspaces")
+	ErrClockifyFailToFetchWorkspaceClients = ClockifyErr("Cannot fetch workspace clients")
 	ErrClockifyTimeEntryUpdateFailed       = ClockifyErr("Cannot update time entry")
 	ErrClockifyTimeEntryTagsIncorrect      = ClockifyErr("Incorrect tags after timentry update")
 	ErrClockifyInaccurateNumberOfTags      = ClockifyErr("Inaccurate number of tags after timeentry update")
@@ -26,10 +32,15 @@ func (e ClockifyErr) Error() string {
 type clockifyApiClient interface {
 	LogRange(_ api.LogRangeParam) ([]dto.TimeEntry, error)
 	GetTags(api.GetTagsParam) ([]dto.Tag, error)
+	GetWorkspaces(api.GetWorkspaces) ([]dto.Workspace, error)
+	GetClients(api.GetClientsParam) ([]dto.Client, error)
 	GetMe() (dto.User, error)
+	GetHydratedTimeEntry(api.GetTimeEntryParam) (*dto.TimeEntry, error)
 	UpdateTimeEntry(api.UpdateTimeEntryParam) (dto.TimeEntryImpl, error)
 }
 
+type User dto.User
+
 type ApiClient struct {
 	client clockifyApiClient
 }
@@ -54,6 +65,18 @@ func NewClient(token string) (*ApiClient, error) {
 	return clockifyApiClient, nil
 }
 
+// GetLoggedInUser returns owner of the clockify token
+func (c *ApiClient) GetLoggedInUser() (User, error) {
+
+	user, err := c.client.GetMe()
+
+	if err != nil {
+		return User{}, ErrClockifyFailToFetchLoggedInUserData
+	}
+
+	return User(user), nil
+}
+
 func (c *ApiClient) GetWorkspaceTags(worskapceId string) (map[string]Tag, error) {
 
 	params := api.GetTagsParam{Workspace: worskapceId}
@@ -91,6 +114,76 @@ func (c *ApiClient) GetTimeEntriesFromGivenPeriod(start, end time.Time, workspac
 	return result, nil
 }
 
+// GetTaggedTimeEntries fetches all pages of time entries of the period tagged with given tag
+func (c *ApiClient) GetTaggedTimeEntries(start, end time.Time, workspaceId, tagId string) ([]TimeEntry, error) {
+
+	logRangeParam, err := c.getLongRangeParameters(start, end, workspaceId)
+
+	if err != nil {
+		return nil, ErrClockifyFailToFetchLoggedInUserData
+	}
+
+	logRangeParam.TagIDs = []string{tagId}
+	logRangeParam.PaginationParam = api.AllPages()
+
+	timeEntries, err := c.client.LogRange(logRangeParam)
+
+	if err != nil {
+		return nil, ErrClockifyFailToFetchTimeEntries
+	}
+
+	return mapTimeEntries(timeEntries), nil
+}
+
+// GetTimeEntriesByIds fetches given time entries of the logged in user in the workspace.
+// Ids which don't exist or belong to another workspace member are returned as missing.
+func (c *ApiClient) GetTimeEntriesByIds(workspaceId string, ids []string) ([]TimeEntry, []string, error) {
+
+	user, err := c.client.GetMe()
+
+	if err != nil {
+		return nil, nil, ErrClockifyFailToFetchLoggedInUserData
+	}
+
+	timeEntries := []dto.TimeEntry{}
+	missing := []string{}
+
+	for _, id := range ids {
+		timeEntry, err := c.client.GetHydratedTimeEntry(api.GetTimeEntryParam{
+			Workspace:   workspaceId,
+			TimeEntryID: id,
+		})
+
+		if err != nil && !isNotFound(err) {
+			return nil, nil, fmt.Errorf("%w: %s: %v", ErrClockifyFailToFetchTimeEntries, id, err)
+		}
+
+		if err != nil || timeEntry == nil || timeEntry.User == nil || timeEntry.User.ID != user.ID {
+			missing = append(missing, id)
+			continue
+		}
+
+		timeEntries = append(timeEntries, *timeEntry)
+	}
+
+	result := mapTimeEntries(timeEntries)
+
+	slices.SortFunc(result, func(a, b TimeEntry) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	return result, missing, nil
+}
+
+// isNotFound reports api errors of ids which don't exist - unknown or malformed ids
+func isNotFound(err error) bool {
+
+	var apiErr dto.Error
+	var invalidIdErr api.InvalidIDError
+
+	return (errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound) || errors.As(err, &invalidIdErr)
+}
+
 func (c *ApiClient) getLongRangeParameters(start, end time.Time, workspaceID string) (api.LogRangeParam, error) {
 
 	userId, err := c.client.GetMe()
@@ -15,6 +15,40 @@ func TestInitClient(t *testing.T) {
 	})
 }
 
+func TestGetLoggedInUser(t *testing.T) {
+
+	t.Run("Get logged in user", func(t *testing.T) {
+		fakeClient := &fakeClient{}
+		fakeClient.getMeSuccessResponse()
+
+		initClient = func(string) (clockifyApiClient, error) {
+			return fakeClient, nil
+		}
+
+		apiClient, _ := NewClient("token")
+
+		user, err := apiClient.GetLoggedInUser()
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, user.ID, "userId")
+	})
+
+	t.Run("Get error on get logged in user", func(t *testing.T) {
+		fakeClient := &fakeClient{}
+		fakeClient.getMeErrorResponse()
+
+		initClient = func(string) (clockifyApiClient, error) {
+			return fakeClient, nil
+		}
+
+		apiClient, _ := NewClient("token")
+
+		_, err := apiClient.GetLoggedInUser()
+
+		assert.Errors(t, err, ErrClockifyFailToFetchLoggedInUserData)
+	})
+}
+
 func TestError(t *testing.T) {
 	t.Run("ErrNotFound", func(t *testing.T) {
 		got := ClockifyErr("Error message").Error()
@@ -13,6 +13,9 @@ type fakeClient struct {
 	logRangeResponse        func() ([]dto.TimeEntry, error)
 	getTagsResponse         func() ([]dto.Tag, error)
 	updateTimeEntryResponse func() (dto.TimeEntryImpl, error)
+	getWorkspacesResponse   func() ([]dto.Workspace, error)
+	getClientsResponse      func() ([]dto.Client, error)
+	getTimeEntryResponse    func(id string) (*dto.TimeEntry, error)
 }
 
 func (f *fakeClient) getTagsSuccessResponse() {
@@ -139,3 +142,71 @@ func (f *fakeClient) updateTimeEntryErrorResponse() {
 func (f *fakeClient) UpdateTimeEntry(api.UpdateTimeEntryParam) (dto.TimeEntryImpl, error) {
 	return f.updateTimeEntryResponse()
 }
+
+func (f *fakeClient) getWorkspacesSuccessResponse() {
+	f.getWorkspacesResponse = func() ([]dto.Workspace, error) {
+		workspaces := []dto.Workspace{
+			{ID: "workspaceId1", Name: "Workspace 1"},
+			{ID: "workspaceId2", Name: "Workspace 2"},
+		}
+
+		return workspaces, nil
+	}
+}
+
+func (f *fakeClient) getWorkspacesErrorResponse() {
+	f.getWorkspacesResponse = func() ([]dto.Workspace, error) {
+		return nil, errors.New("random error")
+	}
+}
+
+func (f *fakeClient) GetWorkspaces(api.GetWorkspaces) ([]dto.Workspace, error) {
+	return f.getWorkspacesResponse()
+}
+
+func (f *fakeClient) getClientsSuccessResponse() {
+	f.getClientsResponse = func() ([]dto.Client, error) {
+		clients := []dto.Client{
+			{ID: "clientId1", Name: "Client 1", WorkspaceID: "workspaceId1"},
+		}
+
+		return clients, nil
+	}
+}
+
+func (f *fakeClient) getClientsErrorResponse() {
+	f.getClientsResponse = func() ([]dto.Client, error) {
+		return nil, errors.New("random error")
+	}
+}
+
+func (f *fakeClient) GetClients(api.GetClientsParam) ([]dto.Client, error) {
+	return f.getClientsResponse()
+}
+
+func (f *fakeClient) getTimeEntrySuccessResponse() {
+	f.getTimeEntryResponse = func(id string) (*dto.TimeEntry, error) {
+		f.logRangeSuccessResponse()
+		timeEntries, _ := f.logRangeResponse()
+
+		for _, timeEntry := range timeEntries {
+			if timeEntry.ID == id {
+				timeEntry.User = &dto.User{ID: "userId"}
+				return &timeEntry, nil
+			}
+		}
+
+		switch id {
+		case "foreign":
+			return &dto.TimeEntry{ID: id, User: &dto.User{ID: "otherUserId"}}, nil
+		case "broken":
+			return nil, errors.New("random-error")
+		}
+
+		return nil, api.ErrorNotFound
+	}
+}
+
+func (f *fakeClient) GetHydratedTimeEntry(p api.GetTimeEntryParam) (*dto.TimeEntry, error) {
+	return f.getTimeEntryResponse(p.TimeEntryID)
+}
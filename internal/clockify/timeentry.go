@@ -12,6 +12,7 @@ type Tag dto.Tag
 type TimeEntry struct {
 	ID          string
 	Description string
+	ClientID    string
 	ClientName  string
 	ProjectID   string
 	ProjectName string
@@ -86,6 +87,7 @@ func mapTimeEntries(timeEntries []dto.TimeEntry) []TimeEntry {
 	for key, timeEntry := range timeEntries {
 		result[key].ID = timeEntry.ID
 		result[key].Description = timeEntry.Description
+		result[key].ClientID = timeEntry.Project.ClientID
 		result[key].ClientName = timeEntry.Project.ClientName
 		result[key].ProjectID = timeEntry.Project.ID
 		result[key].ProjectName = timeEntry.Project.Name
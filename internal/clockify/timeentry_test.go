@@ -99,6 +99,124 @@ func TestGetTimeEntries(t *testing.T) {
 	})
 }
 
+func TestGetTaggedTimeEntries(t *testing.T) {
+
+	t.Run("Get TimeEntries", func(t *testing.T) {
+
+		fakeClient := &fakeClient{}
+		fakeClient.getMeSuccessResponse()
+		fakeClient.logRangeSuccessResponse()
+
+		initClient = func(string) (clockifyApiClient, error) {
+			return fakeClient, nil
+		}
+
+		apiClient, _ := NewClient("token")
+
+		timeEntries, err := apiClient.GetTaggedTimeEntries(time.Now().AddDate(-1, 0, 0), time.Now(), "ws1", "tagId1")
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, len(timeEntries), 2)
+		assert.Strings(t, timeEntries[0].ID, "id1")
+	})
+
+	t.Run("Return error on fetching time entries", func(t *testing.T) {
+
+		fakeClient := &fakeClient{}
+		fakeClient.getMeSuccessResponse()
+		fakeClient.logRangeErrorResponse()
+
+		initClient = func(string) (clockifyApiClient, error) {
+			return fakeClient, nil
+		}
+
+		apiClient, _ := NewClient("token")
+
+		_, err := apiClient.GetTaggedTimeEntries(time.Now().AddDate(-1, 0, 0), time.Now(), "ws1", "tagId1")
+
+		assert.Errors(t, err, ErrClockifyFailToFetchTimeEntries)
+	})
+}
+
+func TestGetTimeEntriesByIds(t *testing.T) {
+
+	t.Run("Get TimeEntries sorted by start", func(t *testing.T) {
+
+		fakeClient := &fakeClient{}
+		fakeClient.getMeSuccessResponse()
+		fakeClient.getTimeEntrySuccessResponse()
+
+		initClient = func(string) (clockifyApiClient, error) {
+			return fakeClient, nil
+		}
+
+		apiClient, _ := NewClient("token")
+
+		timeEntries, missing, err := apiClient.GetTimeEntriesByIds("ws1", []string{"id2", "id1"})
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, len(timeEntries), 2)
+		assert.Strings(t, timeEntries[0].ID, "id1")
+		assert.Strings(t, timeEntries[0].ClientName, "clientName1")
+		assert.Strings(t, timeEntries[1].ID, "id2")
+		assert.StringSlices(t, missing, []string{})
+	})
+
+	t.Run("Return unknown ids and ids of other users as missing", func(t *testing.T) {
+
+		fakeClient := &fakeClient{}
+		fakeClient.getMeSuccessResponse()
+		fakeClient.getTimeEntrySuccessResponse()
+
+		initClient = func(string) (clockifyApiClient, error) {
+			return fakeClient, nil
+		}
+
+		apiClient, _ := NewClient("token")
+
+		timeEntries, missing, err := apiClient.GetTimeEntriesByIds("ws1", []string{"id1", "unknown", "foreign"})
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, len(timeEntries), 1)
+		assert.Strings(t, timeEntries[0].ID, "id1")
+		assert.StringSlices(t, missing, []string{"unknown", "foreign"})
+	})
+
+	t.Run("Throw error on api failure", func(t *testing.T) {
+
+		fakeClient := &fakeClient{}
+		fakeClient.getMeSuccessResponse()
+		fakeClient.getTimeEntrySuccessResponse()
+
+		initClient = func(string) (clockifyApiClient, error) {
+			return fakeClient, nil
+		}
+
+		apiClient, _ := NewClient("token")
+
+		_, _, err := apiClient.GetTimeEntriesByIds("ws1", []string{"id1", "broken"})
+
+		assert.ErrorsIs(t, err, ErrClockifyFailToFetchTimeEntries)
+	})
+
+	t.Run("Throw error when logged in user cannot be fetched", func(t *testing.T) {
+
+		fakeClient := &fakeClient{}
+		fakeClient.getMeErrorResponse()
+		fakeClient.getTimeEntrySuccessResponse()
+
+		initClient = func(string) (clockifyApiClient, error) {
+			return fakeClient, nil
+		}
+
+		apiClient, _ := NewClient("token")
+
+		_, _, err := apiClient.GetTimeEntriesByIds("ws1", []string{"id1"})
+
+		assert.Errors(t, err, ErrClockifyFailToFetchLoggedInUserData)
+	})
+}
+
 func TestUpdateTimeEntry(t *testing.T) {
 
 	t.Run("Update TimeEntry", func(t *testing.T) {
@@ -0,0 +1,52 @@
+package clockify
+
+import (
+	"github.com/lucassabreu/clockify-cli/api"
+	"github.com/lucassabreu/clockify-cli/api/dto"
+)
+
+type Workspace dto.Workspace
+
+type Client dto.Client
+
+func (c *ApiClient) GetWorkspaces() ([]Workspace, error) {
+
+	workspaces, err := c.client.GetWorkspaces(api.GetWorkspaces{})
+
+	if err != nil {
+		return nil, ErrClockifyFailToFetchWorkspaces
+	}
+
+	result := make([]Workspace, 0, len(workspaces))
+
+	for _, workspace := range workspaces {
+		result = append(result, Workspace(workspace))
+	}
+
+	return result, nil
+}
+
+// GetWorkspaceClients returns not archived clients of given workspace
+func (c *ApiClient) GetWorkspaceClients(workspaceId string) ([]Client, error) {
+
+	archived := false
+	params := api.GetClientsParam{
+		Workspace:       workspaceId,
+		Archived:        &archived,
+		PaginationParam: api.AllPages(),
+	}
+
+	clients, err := c.client.GetClients(params)
+
+	if err != nil {
+		return nil, ErrClockifyFailToFetchWorkspaceClients
+	}
+
+	result := make([]Client, 0, len(clients))
+
+	for _, client := range clients {
+		result = append(result, Client(client))
+	}
+
+	return result, nil
+}
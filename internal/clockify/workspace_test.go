@@ -0,0 +1,78 @@
+package clockify
+
+import (
+	"testing"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+func TestGetWorkspaces(t *testing.T) {
+
+	t.Run("Get workspaces", func(t *testing.T) {
+		fakeClient := &fakeClient{}
+		fakeClient.getWorkspacesSuccessResponse()
+
+		initClient = func(string) (clockifyApiClient, error) {
+			return fakeClient, nil
+		}
+
+		apiClient, _ := NewClient("token")
+
+		workspaces, err := apiClient.GetWorkspaces()
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, len(workspaces), 2)
+		assert.Strings(t, workspaces[0].ID, "workspaceId1")
+		assert.Strings(t, workspaces[1].Name, "Workspace 2")
+	})
+
+	t.Run("Get error on get workspaces", func(t *testing.T) {
+		fakeClient := &fakeClient{}
+		fakeClient.getWorkspacesErrorResponse()
+
+		initClient = func(string) (clockifyApiClient, error) {
+			return fakeClient, nil
+		}
+
+		apiClient, _ := NewClient("token")
+
+		_, err := apiClient.GetWorkspaces()
+
+		assert.Errors(t, err, ErrClockifyFailToFetchWorkspaces)
+	})
+}
+
+func TestGetWorkspaceClients(t *testing.T) {
+
+	t.Run("Get workspace clients", func(t *testing.T) {
+		fakeClient := &fakeClient{}
+		fakeClient.getClientsSuccessResponse()
+
+		initClient = func(string) (clockifyApiClient, error) {
+			return fakeClient, nil
+		}
+
+		apiClient, _ := NewClient("token")
+
+		clients, err := apiClient.GetWorkspaceClients("workspaceId1")
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, len(clients), 1)
+		assert.Strings(t, clients[0].Name, "Client 1")
+	})
+
+	t.Run("Get error on get workspace clients", func(t *testing.T) {
+		fakeClient := &fakeClient{}
+		fakeClient.getClientsErrorResponse()
+
+		initClient = func(string) (clockifyApiClient, error) {
+			return fakeClient, nil
+		}
+
+		apiClient, _ := NewClient("token")
+
+		_, err := apiClient.GetWorkspaceClients("workspaceId1")
+
+		assert.Errors(t, err, ErrClockifyFailToFetchWorkspaceClients)
+	})
+}
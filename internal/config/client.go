@@ -1,45 +1,203 @@
 package config
 
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	undecomposableLetters = strings.NewReplacer("ł", "l", "đ", "d", "ø", "o", "ß", "ss", "æ", "ae", "œ", "oe")
+	clientNameSeparators  = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+)
+
 type Client struct {
-	JiraClientUser string `yaml:"jira_client_user"`
-	JiraHost       string `yaml:"jira_host"`
-	JiraUsername   string `yaml:"jira_username"`
-	JiraPassword   string `yaml:"jira_password"`
-	StachurskyMode int    `yaml:"stachursky_mode"`
-	Enabled        bool   `yaml:"enabled"`
+	ClockifyClientId    string   `yaml:"clockify_client_id,omitempty"`
+	Aliases             []string `yaml:"aliases,omitempty"`
+	Profile             string   `yaml:"profile,omitempty"`
+	JiraClientUser      string   `yaml:"jira_client_user,omitempty"`
+	JiraHost            string   `yaml:"jira_host,omitempty"`
+	JiraUsername        string   `yaml:"jira_username,omitempty"`
+	JiraPassword        string   `yaml:"jira_password,omitempty"`
+	JiraPasswordCommand string   `yaml:"jira_password_command,omitempty"`
+	JiraApiVersion      int      `yaml:"jira_api_version,omitempty"`
+	JiraProjects        []string `yaml:"jira_projects,omitempty"`
+	StachurskyMode      int      `yaml:"stachursky_mode,omitempty"`
+	RoundingStrategy    string   `yaml:"rounding_strategy,omitempty"`
+	RoundingGrace       int      `yaml:"rounding_grace,omitempty"`
+	RoundingMinimum     int      `yaml:"rounding_minimum,omitempty"`
+	Aggregation         string   `yaml:"aggregation,omitempty"`
+	MergeGap            int      `yaml:"merge_gap,omitempty"`
+	MinDuration         int      `yaml:"min_duration,omitempty"`
+	MinDurationAction   string   `yaml:"min_duration_action,omitempty"`
+	DailyCap            int      `yaml:"daily_cap,omitempty"`
+	WeeklyCap           int      `yaml:"weekly_cap,omitempty"`
+	CapAction           string   `yaml:"cap_action,omitempty"`
+	Enabled             bool     `yaml:"enabled"`
+
+	keys    presence
+	sources sources
+}
+
+// combineWithDefaultConfig resolves client settings: default client -> profile (optional) -> client entry
+func (c *Client) combineWithDefaultConfig(defaultClient Client, profile *Client) *Client {
+
+	base := defaultClient
+	baseSources := defaultClient.keys.sources(SourceDefault, nil)
+
+	if profile != nil {
+		base = profile.override(defaultClient)
+		base.Aliases = profile.Aliases
+		baseSources = profile.keys.sources(SourceProfile, baseSources)
+	}
+
+	client := c.override(base)
+
+	// clockify client id is never inherited, aliases can be shared only by profile
+	client.ClockifyClientId = c.ClockifyClientId
+
+	if profile == nil || c.keys.overrides("aliases", len(c.Aliases) != 0) {
+		client.Aliases = c.Aliases
+	}
+
+	client.Profile = c.Profile
+	client.keys = c.keys
+	client.sources = c.keys.sources(SourceClient, baseSources)
+
+	return &client
 }
 
-func (c *Client) combineWithDefaultConfig(defaultClient Client) *Client {
+// override returns base client with settings set in c
+func (c *Client) override(base Client) Client {
 
-	client := defaultClient
+	client := base
 
-	if c.JiraClientUser != "" {
+	if c.keys.overrides("jira_client_user", c.JiraClientUser != "") {
 		client.JiraClientUser = c.JiraClientUser
 	}
 
-	if c.JiraPassword != "" {
+	if c.keys.overrides("jira_password", c.JiraPassword != "") {
 		client.JiraPassword = c.JiraPassword
+		client.JiraPasswordCommand = ""
 	}
 
-	if c.JiraUsername != "" {
+	if c.keys.overrides("jira_password_command", c.JiraPasswordCommand != "") {
+		client.JiraPasswordCommand = c.JiraPasswordCommand
+		client.JiraPassword = c.JiraPassword
+	}
+
+	if c.keys.overrides("jira_username", c.JiraUsername != "") {
 		client.JiraUsername = c.JiraUsername
 	}
 
-	if c.JiraHost != "" {
+	if c.keys.overrides("jira_host", c.JiraHost != "") {
 		client.JiraHost = c.JiraHost
 	}
 
-	if c.StachurskyMode != 0 {
+	if c.keys.overrides("jira_api_version", c.JiraApiVersion != 0) {
+		client.JiraApiVersion = c.JiraApiVersion
+	}
+
+	if c.keys.overrides("jira_projects", len(c.JiraProjects) != 0) {
+		client.JiraProjects = c.JiraProjects
+	}
+
+	if c.keys.overrides("stachursky_mode", c.StachurskyMode != 0) {
 		client.StachurskyMode = c.StachurskyMode
 	}
 
-	if c.Enabled {
+	if c.keys.overrides("rounding_strategy", c.RoundingStrategy != "") {
+		client.RoundingStrategy = c.RoundingStrategy
+	}
+
+	if c.keys.overrides("rounding_grace", c.RoundingGrace != 0) {
+		client.RoundingGrace = c.RoundingGrace
+	}
+
+	if c.keys.overrides("rounding_minimum", c.RoundingMinimum != 0) {
+		client.RoundingMinimum = c.RoundingMinimum
+	}
+
+	if c.keys.overrides("aggregation", c.Aggregation != "") {
+		client.Aggregation = c.Aggregation
+	}
+
+	if c.keys.overrides("merge_gap", c.MergeGap != 0) {
+		client.MergeGap = c.MergeGap
+	}
+
+	if c.keys.overrides("min_duration", c.MinDuration != 0) {
+		client.MinDuration = c.MinDuration
+	}
+
+	if c.keys.overrides("min_duration_action", c.MinDurationAction != "") {
+		client.MinDurationAction = c.MinDurationAction
+	}
+
+	if c.keys.overrides("daily_cap", c.DailyCap != 0) {
+		client.DailyCap = c.DailyCap
+	}
+
+	if c.keys.overrides("weekly_cap", c.WeeklyCap != 0) {
+		client.WeeklyCap = c.WeeklyCap
+	}
+
+	if c.keys.overrides("cap_action", c.CapAction != "") {
+		client.CapAction = c.CapAction
+	}
+
+	if c.keys.overrides("enabled", c.Enabled) {
 		client.Enabled = c.Enabled
 	}
 
-	return &client
+	return client
 }
 
 func (c *Client) overwritePrecisionSetting(precision int) {
 	c.StachurskyMode = precision
+	c.sources = c.sources.set("stachursky_mode", SourceFlag)
+}
+
+// setDefaultPrecision sets precision of clients without stachursky_mode key in any layer
+func (c *Client) setDefaultPrecision(precision int) {
+
+	if c.StachurskyMode == 0 && !c.sources.has("stachursky_mode") {
+		c.overwritePrecisionSetting(precision)
+	}
+}
+
+func (c *Client) hasJiraHost(jiraHost string) bool {
+	return normalizeJiraHost(c.JiraHost) != "" && normalizeJiraHost(c.JiraHost) == normalizeJiraHost(jiraHost)
+}
+
+// NormalizeClientName makes client names comparable regardless of case, diacritics, spaces and punctuation
+// e.g. "Łódź Sp. z o.o." -> "lodz_sp_z_o_o"
+func NormalizeClientName(name string) string {
+
+	// transformer is stateful, so it cannot be shared between goroutines
+	diacriticsRemover := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	decomposed, _, err := transform.String(diacriticsRemover, strings.ToLower(name))
+
+	if err != nil {
+		decomposed = strings.ToLower(name)
+	}
+
+	decomposed = undecomposableLetters.Replace(decomposed)
+
+	return strings.Trim(clientNameSeparators.ReplaceAllString(decomposed, "_"), "_")
+}
+
+func normalizeJiraHost(jiraHost string) string {
+	parsedHost, err := url.Parse(strings.TrimSpace(jiraHost))
+
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(parsedHost.Host)
 }
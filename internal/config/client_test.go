@@ -15,6 +15,7 @@ const (
 	jiraPasswordDefault   = "jiraPasswordDefault"
 	jiraUsernameDefault   = "jiraUsernameDefault"
 	jiraHostDefault       = "jiraHostDefault"
+	jiraApiVersionDefault = 2
 	stachurskyModeDefault = 30
 	enabledDefault        = false
 
@@ -22,6 +23,7 @@ const (
 	jiraPassword   = "jiraPassword"
 	jiraUsername   = "jiraUsername"
 	jiraHost       = "jiraHost"
+	jiraApiVersion = 3
 	stachurskyMode = 15
 	enabled        = true
 )
@@ -32,6 +34,7 @@ var (
 		JiraHost:       jiraHostDefault,
 		JiraPassword:   jiraPasswordDefault,
 		JiraUsername:   jiraUsernameDefault,
+		JiraApiVersion: jiraApiVersionDefault,
 		StachurskyMode: stachurskyModeDefault,
 		Enabled:        enabledDefault,
 	}
@@ -56,12 +59,13 @@ func TestGetClientConfig(t *testing.T) {
 		JiraHost:       jiraHost,
 		JiraPassword:   jiraPassword,
 		JiraUsername:   jiraUsername,
+		JiraApiVersion: jiraApiVersion,
 		StachurskyMode: stachurskyMode,
 		Enabled:        enabled,
 	}
 
 	t.Run("Get client config", func(t *testing.T) {
-		client := clientId1.combineWithDefaultConfig(defaultClient)
+		client := clientId1.combineWithDefaultConfig(defaultClient, nil)
 
 		assert.Strings(t, client.JiraClientUser, jiraClientUser)
 		assert.Strings(t, client.JiraPassword, jiraPassword)
@@ -73,28 +77,83 @@ func TestGetClientConfig(t *testing.T) {
 
 	t.Run("Partially combine with default client config", func(t *testing.T) {
 
-		finalClient := clientId2.combineWithDefaultConfig(defaultClient)
+		finalClient := clientId2.combineWithDefaultConfig(defaultClient, nil)
 
 		assert.Strings(t, finalClient.JiraClientUser, jiraClientUser)
 		assert.Strings(t, finalClient.JiraPassword, jiraPasswordDefault)
 		assert.Strings(t, finalClient.JiraUsername, jiraUsernameDefault)
 		assert.Strings(t, finalClient.JiraHost, jiraHost)
+		assert.Ints(t, finalClient.JiraApiVersion, jiraApiVersionDefault)
 		assert.Ints(t, finalClient.StachurskyMode, stachurskyModeDefault)
 		assert.Bools(t, finalClient.Enabled, enabledDefault)
 	})
 
 	t.Run("Override default client config", func(t *testing.T) {
-		finalClient := clientId3.combineWithDefaultConfig(defaultClient)
+		finalClient := clientId3.combineWithDefaultConfig(defaultClient, nil)
 
 		assert.Strings(t, finalClient.JiraClientUser, jiraClientUser)
 		assert.Strings(t, finalClient.JiraPassword, jiraPassword)
 		assert.Strings(t, finalClient.JiraUsername, jiraUsername)
 		assert.Strings(t, finalClient.JiraHost, jiraHost)
+		assert.Ints(t, finalClient.JiraApiVersion, jiraApiVersion)
 		assert.Ints(t, finalClient.StachurskyMode, stachurskyMode)
 		assert.Bools(t, finalClient.Enabled, enabled)
 	})
 }
 
+func TestCombineClientRoundingConfig(t *testing.T) {
+	defaultClient := Client{
+		RoundingStrategy: "nearest",
+		RoundingGrace:    5,
+		Aggregation:      "issue_day",
+		MinDuration:      60,
+		DailyCap:         480,
+		MergeGap:         5,
+	}
+
+	t.Run("Inherit default rounding config", func(t *testing.T) {
+		client := Client{}
+		finalClient := client.combineWithDefaultConfig(defaultClient, nil)
+
+		assert.Strings(t, finalClient.RoundingStrategy, "nearest")
+		assert.Ints(t, finalClient.RoundingGrace, 5)
+		assert.Ints(t, finalClient.RoundingMinimum, 0)
+		assert.Strings(t, finalClient.Aggregation, "issue_day")
+		assert.Ints(t, finalClient.MinDuration, 60)
+		assert.Strings(t, finalClient.MinDurationAction, "")
+		assert.Ints(t, finalClient.DailyCap, 480)
+		assert.Ints(t, finalClient.WeeklyCap, 0)
+		assert.Ints(t, finalClient.MergeGap, 5)
+	})
+
+	t.Run("Override default rounding config", func(t *testing.T) {
+		client := Client{
+			RoundingStrategy:  "ceil",
+			RoundingGrace:     3,
+			RoundingMinimum:   30,
+			Aggregation:       "none",
+			MinDuration:       30,
+			MinDurationAction: "merge",
+			DailyCap:          360,
+			WeeklyCap:         1800,
+			CapAction:         "block",
+			MergeGap:          2,
+		}
+		finalClient := client.combineWithDefaultConfig(defaultClient, nil)
+
+		assert.Strings(t, finalClient.RoundingStrategy, "ceil")
+		assert.Ints(t, finalClient.RoundingGrace, 3)
+		assert.Ints(t, finalClient.RoundingMinimum, 30)
+		assert.Strings(t, finalClient.Aggregation, "none")
+		assert.Ints(t, finalClient.MinDuration, 30)
+		assert.Strings(t, finalClient.MinDurationAction, "merge")
+		assert.Ints(t, finalClient.DailyCap, 360)
+		assert.Ints(t, finalClient.WeeklyCap, 1800)
+		assert.Strings(t, finalClient.CapAction, "block")
+		assert.Ints(t, finalClient.MergeGap, 2)
+	})
+}
+
 func TestOverwriteClientPrecisionConfig(t *testing.T) {
 	client := Client{
 		StachurskyMode: 10,
@@ -104,3 +163,72 @@ func TestOverwriteClientPrecisionConfig(t *testing.T) {
 
 	assert.Ints(t, client.StachurskyMode, 70)
 }
+
+func TestCombineClientProfileConfig(t *testing.T) {
+
+	config, err := Load([]byte(`version: 1
+default_client:
+  jira_host: https://jira.atlassian.net
+  stachursky_mode: 15
+  enabled: true
+clients:
+  acme:
+    aliases:
+      - ACME Inc.
+    jira_host: https://acme.atlassian.net
+    jira_username: username@acme.com
+    stachursky_mode: 30
+workspaces:
+  ws_1:
+    clients:
+      acme:
+        profile: acme
+        clockify_client_id: client-id-1
+  ws_2:
+    clients:
+      acme_eu:
+        profile: acme
+        aliases:
+          - ACME Europe
+        stachursky_mode: 0
+        enabled: false
+`))
+
+	assert.Errors(t, err, nil)
+
+	t.Run("Resolve default client, profile and workspace client in order", func(t *testing.T) {
+		client := config.Workspaces["ws_1"].Clients["acme"]
+
+		assert.Strings(t, client.JiraHost, "https://acme.atlassian.net")
+		assert.Strings(t, client.JiraUsername, "username@acme.com")
+		assert.Strings(t, client.ClockifyClientId, "client-id-1")
+		assert.StringSlices(t, client.Aliases, []string{"ACME Inc."})
+		assert.Ints(t, client.StachurskyMode, 30)
+		assert.Bools(t, client.Enabled, true)
+		assert.Strings(t, client.sources["jira_host"], SourceProfile)
+		assert.Strings(t, client.sources["enabled"], SourceDefault)
+		assert.Strings(t, client.sources["clockify_client_id"], SourceClient)
+	})
+
+	t.Run("Override profile settings in workspace client", func(t *testing.T) {
+		client := config.Workspaces["ws_2"].Clients["acme_eu"]
+
+		assert.Strings(t, client.JiraHost, "https://acme.atlassian.net")
+		assert.StringSlices(t, client.Aliases, []string{"ACME Europe"})
+		assert.Ints(t, client.StachurskyMode, 0)
+		assert.Bools(t, client.Enabled, false)
+		assert.Strings(t, client.sources["stachursky_mode"], SourceClient)
+	})
+}
+
+func TestClientJiraProjects(t *testing.T) {
+	defaultClient := Client{JiraProjects: []string{"ABC"}}
+
+	t.Run("Inherit and override jira projects", func(t *testing.T) {
+		inherited := (&Client{}).combineWithDefaultConfig(defaultClient, nil)
+		overridden := (&Client{JiraProjects: []string{"XYZ", "OPS"}}).combineWithDefaultConfig(defaultClient, nil)
+
+		assert.StringSlices(t, inherited.JiraProjects, []string{"ABC"})
+		assert.StringSlices(t, overridden.JiraProjects, []string{"XYZ", "OPS"})
+	})
+}
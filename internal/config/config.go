@@ -3,12 +3,16 @@ package config
 import (
 	"slices"
 	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/period"
 )
 
 const (
 	ErrWorkspaceNotFound             = ConfigErr("Cannot find workspace configuration")
 	ErrWorkspacesNotConfigured       = ConfigErr("Cannot find workspaces in configuration")
 	ErrWorkspacesNotMatchingSelector = ConfigErr("Cannot find workspaces matching provided selector")
+
+	DefaultRetryLimit = 3
 )
 
 type ConfigErr string
@@ -20,15 +24,36 @@ func (e ConfigErr) Error() string {
 type Workspaces map[string]*Workspace
 
 type Global struct {
-	ClockifyToken string `yaml:"clockify_token"`
-	Period        int    `yaml:"period"`
+	ClockifyToken        string `yaml:"clockify_token"`
+	ClockifyTokenCommand string `yaml:"clockify_token_command,omitempty"`
+	Period               int    `yaml:"period"`
+	DailyCap             int    `yaml:"daily_cap,omitempty"`
+	WeeklyCap            int    `yaml:"weekly_cap,omitempty"`
+	CapAction            string `yaml:"cap_action,omitempty"`
+	Timezone             string `yaml:"timezone,omitempty"`
+	WeekStart            string `yaml:"week_start,omitempty"`
+	RetryLimit           int    `yaml:"retry_limit,omitempty"`
+
+	keys    presence
+	sources sources
 }
 
 type Config struct {
+	Version          int        `yaml:"version"`
 	Global           Global     `yaml:"global"`
 	DefaultClient    Client     `yaml:"default_client"`
 	DefaultWorkspace Workspace  `yaml:"default_workspace"`
 	Workspaces       Workspaces `yaml:"workspaces"`
+	// Clients are client profiles referenced by workspace clients with profile key
+	Clients Clients `yaml:"clients,omitempty"`
+	// SourceVersion is the layout version of loaded file before migration
+	SourceVersion int `yaml:"-"`
+	// Files are loaded configuration files in merge order
+	Files []string `yaml:"-"`
+
+	// date range set with --from / --to flags replaces period
+	rangeStart time.Time
+	rangeEnd   time.Time
 }
 
 func (c *Config) GetWorkspace(workspaceId string) (*Workspace, error) {
@@ -63,6 +88,7 @@ func (c *Config) FindWorkspaces(workspaceSelector []string) (Workspaces, error)
 
 func (c *Config) OverwritePeriodSetting(period int) {
 	c.Global.Period = period
+	c.Global.sources = c.Global.sources.set("period", SourceFlag)
 }
 
 func (c *Config) OverwritePrecisionSetting(precision int) {
@@ -73,8 +99,62 @@ func (c *Config) OverwritePrecisionSetting(precision int) {
 	}
 }
 
+// SetDefaultPrecision sets precision of clients without stachursky_mode
+func (c *Config) SetDefaultPrecision(precision int) {
+
+	for key := range c.Workspaces {
+		c.Workspaces[key].setDefaultPrecision(precision)
+	}
+}
+
+// OverwriteDateRange replaces period with range between from and to date expressions
+func (c *Config) OverwriteDateRange(from, to string, now time.Time) error {
+
+	start, end, err := c.Calendar().Between(from, to, now)
+
+	if err != nil {
+		return err
+	}
+
+	c.rangeStart, c.rangeEnd = start, end
+
+	return nil
+}
+
+// GetTimeInterval returns date range or period days aligned to the beginning of the first day
 func (c *Config) GetTimeInterval(now *time.Time) (time.Time, time.Time) {
-	return now.AddDate(0, 0, -c.Global.Period), *now
+
+	if !c.rangeStart.IsZero() {
+		return c.rangeStart, c.rangeEnd
+	}
+
+	return c.Calendar().LastDays(c.Global.Period, *now)
+}
+
+// GetRetryLimit returns number of failed migration attempts after which --retry-failed no longer retries time entry
+func (c *Config) GetRetryLimit() int {
+
+	if c.Global.RetryLimit == 0 {
+		return DefaultRetryLimit
+	}
+
+	return c.Global.RetryLimit
+}
+
+// Calendar uses configured timezone (local by default) and week start (monday by default)
+func (c *Config) Calendar() period.Calendar {
+
+	calendar := period.Calendar{Location: time.Local, WeekStart: time.Monday}
+
+	if location, err := time.LoadLocation(c.Global.Timezone); err == nil && c.Global.Timezone != "" {
+		calendar.Location = location
+	}
+
+	if weekStart, err := period.ParseWeekday(c.Global.WeekStart); err == nil {
+		calendar.WeekStart = weekStart
+	}
+
+	return calendar
 }
 
 func (c *Config) combineWithDefaultConfig() Workspaces {
@@ -82,7 +162,7 @@ func (c *Config) combineWithDefaultConfig() Workspaces {
 	workspaceList := Workspaces{}
 
 	for key, workspace := range c.Workspaces {
-		workspaceList[key] = workspace.combineWithDefaultConfig(c.DefaultWorkspace, c.DefaultClient)
+		workspaceList[key] = workspace.combineWithDefaultConfig(c.DefaultWorkspace, c.DefaultClient, c.Clients)
 	}
 
 	return workspaceList
@@ -9,7 +9,7 @@ import (
 
 const (
 	clockifyToken = "clockifyToken"
-	period        = 1
+	periodDays    = 1
 
 	workspaceId1 = "workspaceId1"
 	workspaceId2 = "workspaceId2"
@@ -31,7 +31,7 @@ var (
 	config = Config{
 		Global: Global{
 			ClockifyToken: clockifyToken,
-			Period:        period,
+			Period:        periodDays,
 		},
 
 		DefaultClient: Client{
@@ -190,14 +190,29 @@ func TestConfigOverwrites(t *testing.T) {
 
 func TestGetTimeInterval(t *testing.T) {
 
-	now := time.Now()
+	now := time.Date(2025, time.January, 15, 9, 45, 0, 0, time.Local)
 
-	start, end := config.GetTimeInterval(&now)
+	t.Run("Align period to the beginning of the first day", func(t *testing.T) {
+		periodConfig := Config{Global: Global{Period: periodDays}}
 
-	expectedStart := now.AddDate(0, 0, -config.Global.Period)
+		start, end := periodConfig.GetTimeInterval(&now)
 
-	assert.Strings(t, start.String(), expectedStart.String())
-	assert.Strings(t, end.String(), now.String())
+		assert.Strings(t, start.String(), time.Date(2025, time.January, 14, 0, 0, 0, 0, time.Local).String())
+		assert.Strings(t, end.String(), now.String())
+	})
+
+	t.Run("Use date range instead of period", func(t *testing.T) {
+		rangeConfig := Config{Global: Global{Period: 7, Timezone: "UTC", WeekStart: "sunday"}}
+
+		err := rangeConfig.OverwriteDateRange("last-week", "last-week", now)
+
+		assert.Errors(t, err, nil)
+
+		start, end := rangeConfig.GetTimeInterval(&now)
+
+		assert.Strings(t, start.String(), "2025-01-05 00:00:00 +0000 UTC")
+		assert.Strings(t, end.String(), "2025-01-12 00:00:00 +0000 UTC")
+	})
 }
 
 func TestError(t *testing.T) {
@@ -0,0 +1,193 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	FormatYaml = "yaml"
+	FormatJson = "json"
+	FormatToml = "toml"
+
+	ErrFormatNotYaml = ConfigErr("Only yaml configuration files can be written - update json and toml files by hand")
+)
+
+// FileFormat detects configuration format by file extension, unknown extensions are read as yaml
+func FileFormat(filePath string) string {
+
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".json":
+		return FormatJson
+	case ".toml":
+		return FormatToml
+	default:
+		return FormatYaml
+	}
+}
+
+// decodeFile decodes configuration file of any supported format into yaml document
+func decodeFile(filePath string, fileData []byte) (yaml.MapSlice, error) {
+
+	switch FileFormat(filePath) {
+	case FormatJson:
+		return decodeJsonDocument(fileData)
+	case FormatToml:
+		return decodeTomlDocument(fileData)
+	default:
+		return decodeDocument(fileData)
+	}
+}
+
+// decodeJsonDocument keeps order of json object keys, so migrated and shown configuration looks like the file
+func decodeJsonDocument(jsonData []byte) (yaml.MapSlice, error) {
+
+	decoder := json.NewDecoder(bytes.NewReader(jsonData))
+	decoder.UseNumber()
+
+	value, err := decodeJsonValue(decoder)
+
+	if err == nil && decoder.More() {
+		err = fmt.Errorf("unexpected data after top level object")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLoaderInvalidConfiguration, err)
+	}
+
+	document, ok := value.(yaml.MapSlice)
+
+	if !ok {
+		return nil, fmt.Errorf("%w: top level value has to be an object", ErrLoaderInvalidConfiguration)
+	}
+
+	return document, nil
+}
+
+func decodeJsonValue(decoder *json.Decoder) (interface{}, error) {
+
+	token, err := decoder.Token()
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		if token == '[' {
+			items := []interface{}{}
+
+			for decoder.More() {
+				item, err := decodeJsonValue(decoder)
+
+				if err != nil {
+					return nil, err
+				}
+
+				items = append(items, item)
+			}
+
+			_, err = decoder.Token()
+
+			return items, err
+		}
+
+		object := yaml.MapSlice{}
+
+		for decoder.More() {
+			key, err := decoder.Token()
+
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := decodeJsonValue(decoder)
+
+			if err != nil {
+				return nil, err
+			}
+
+			object = append(object, yaml.MapItem{Key: key, Value: value})
+		}
+
+		_, err = decoder.Token()
+
+		return object, err
+	case json.Number:
+		if number, err := token.Int64(); err == nil {
+			return int(number), nil
+		}
+
+		return token.Float64()
+	default:
+		return token, nil
+	}
+}
+
+func decodeTomlDocument(tomlData []byte) (yaml.MapSlice, error) {
+
+	values := map[string]interface{}{}
+
+	_, err := toml.Decode(string(tomlData), &values)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLoaderInvalidConfiguration, err)
+	}
+
+	return tomlTable(values), nil
+}
+
+// tomlTable converts toml table into yaml document, toml tables are not ordered so keys are sorted
+func tomlTable(values map[string]interface{}) yaml.MapSlice {
+
+	keys := make([]string, 0, len(values))
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	document := make(yaml.MapSlice, 0, len(keys))
+
+	for _, key := range keys {
+		document = append(document, yaml.MapItem{Key: key, Value: tomlValue(values[key])})
+	}
+
+	return document
+}
+
+func tomlValue(value interface{}) interface{} {
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		return tomlTable(value)
+	case []map[string]interface{}:
+		items := make([]interface{}, 0, len(value))
+
+		for _, item := range value {
+			items = append(items, tomlTable(item))
+		}
+
+		return items
+	case []interface{}:
+		items := make([]interface{}, 0, len(value))
+
+		for _, item := range value {
+			items = append(items, tomlValue(item))
+		}
+
+		return items
+	case int64:
+		return int(value)
+	default:
+		return value
+	}
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+const (
+	jsonConfig = `{
+  "version": 1,
+  "global": {"clockify_token": "clockify-token", "period": 3},
+  "default_client": {
+    "jira_host": "https://jira.atlassian.net",
+    "jira_username": "username@domain.com",
+    "jira_password": "jira-password",
+    "stachursky_mode": 15
+  },
+  "default_workspace": {
+    "jira_migration_failed_tag": "failed",
+    "jira_migration_skip_tag": "skipped",
+    "jira_migration_success_tag": "logged"
+  },
+  "workspaces": {
+    "ws_1": {
+      "workspace_id": "ws-1",
+      "clients": {
+        "client_1": {"enabled": true, "aliases": ["Client One"], "merge_gap": 5}
+      }
+    }
+  }
+}
+`
+	tomlConfig = `version = 1
+
+[global]
+clockify_token = "clockify-token"
+period = 3
+
+[default_client]
+jira_host = "https://jira.atlassian.net"
+jira_username = "username@domain.com"
+jira_password = "jira-password"
+stachursky_mode = 15
+
+[default_workspace]
+jira_migration_failed_tag = "failed"
+jira_migration_skip_tag = "skipped"
+jira_migration_success_tag = "logged"
+
+[workspaces.ws_1]
+workspace_id = "ws-1"
+
+[workspaces.ws_1.clients.client_1]
+enabled = true
+aliases = ["Client One"]
+merge_gap = 5
+`
+)
+
+func TestLoadConfigFormats(t *testing.T) {
+
+	dir := t.TempDir()
+
+	for _, fileName := range []string{"config.json", "config.toml"} {
+		t.Run("Load "+fileName, func(t *testing.T) {
+			content := jsonConfig
+
+			if FileFormat(fileName) == FormatToml {
+				content = tomlConfig
+			}
+
+			filePath := writeLayerFile(t, filepath.Join(dir, fileName), content)
+
+			config, err := LoadFromFile(filePath)
+
+			assert.Errors(t, err, nil)
+			assert.Ints(t, config.Global.Period, 3)
+
+			client := config.Workspaces["ws_1"].Clients["client_1"]
+			assert.Bools(t, client.Enabled, true)
+			assert.StringSlices(t, client.Aliases, []string{"Client One"})
+			assert.Ints(t, client.MergeGap, 5)
+			assert.Ints(t, client.StachurskyMode, 15)
+			assert.Strings(t, client.JiraHost, "https://jira.atlassian.net")
+		})
+	}
+
+	t.Run("Report json problems with file and line", func(t *testing.T) {
+		filePath := writeLayerFile(t, filepath.Join(dir, "invalid.json"), strings.Replace(jsonConfig, `"merge_gap": 5`, `"merge_gap": -5`, 1))
+
+		problems, err := ValidateFile(filePath)
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, len(problems), 1)
+		assert.Strings(t, problems[0].String(), filePath+":19: workspaces.ws_1.clients.client_1.merge_gap: cannot be negative")
+	})
+
+	t.Run("Throw error on invalid json", func(t *testing.T) {
+		filePath := writeLayerFile(t, filepath.Join(dir, "broken.json"), `{"global": {"period": 3}`)
+
+		_, err := LoadFromFile(filePath)
+
+		assert.ErrorsIs(t, err, ErrLoaderInvalidConfiguration)
+	})
+
+	t.Run("Throw error on invalid toml", func(t *testing.T) {
+		filePath := writeLayerFile(t, filepath.Join(dir, "broken.toml"), "[global\nperiod = 3\n")
+
+		_, err := LoadFromFile(filePath)
+
+		assert.ErrorsIs(t, err, ErrLoaderInvalidConfiguration)
+	})
+
+	t.Run("Show toml file as yaml", func(t *testing.T) {
+		filePath := filepath.Join(dir, "config.toml")
+
+		got, err := ShowFile(filePath)
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, strings.HasPrefix(got, "version: 1\n"), true)
+		assert.Bools(t, strings.Contains(got, "\n  jira_password: '********'\n"), true)
+	})
+
+	t.Run("Refuse to rewrite json file", func(t *testing.T) {
+		filePath := filepath.Join(dir, "config.json")
+
+		_, err := MigrateYamlFile(filePath, time.Now())
+
+		assert.Errors(t, err, ErrFormatNotYaml)
+
+		content, _ := os.ReadFile(filePath)
+		assert.Strings(t, string(content), jsonConfig)
+	})
+}
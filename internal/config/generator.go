@@ -1,7 +1,9 @@
 package config
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path"
@@ -16,17 +18,20 @@ const (
 
 var (
 	configTemplate = Config{
+		Version: CurrentVersion,
 		Global: Global{
 			ClockifyToken: "(visit https://app.clockify.me/user/preferences#advanced)",
 			Period:        7,
 		},
 		DefaultClient: Client{
-			JiraClientUser: "firstname.lastname",
-			JiraHost:       "https://headstart.atlassian.net",
-			JiraUsername:   "firstname.lastname@domain.com",
-			JiraPassword:   "(visit https://id.atlassian.com/manage/api-tokens)",
-			StachurskyMode: 15,
-			Enabled:        false,
+			JiraClientUser:   "firstname.lastname",
+			JiraHost:         "https://headstart.atlassian.net",
+			JiraUsername:     "firstname.lastname@domain.com",
+			JiraPassword:     "(visit https://id.atlassian.com/manage/api-tokens)",
+			JiraApiVersion:   2,
+			StachurskyMode:   15,
+			RoundingStrategy: "nearest",
+			Enabled:          false,
 		},
 		DefaultWorkspace: Workspace{
 			WorkspaceId:             "(visit https://app.clockify.me/workspaces -> settings -> id from url)",
@@ -39,38 +44,69 @@ var (
 )
 
 func InitializeConfig(filePath string) error {
+	return SaveConfig(filePath, configTemplate)
+}
+
+// NewConfigFromTemplate returns configuration template with given clockify token and workspaces
+func NewConfigFromTemplate(clockifyToken string, workspaces Workspaces) Config {
+
+	config := configTemplate
+	config.Global.ClockifyToken = clockifyToken
+	config.DefaultWorkspace.WorkspaceId = ""
+	config.Workspaces = workspaces
+
+	return config
+}
 
-	fileExists := checkFileExists(filePath)
+// SaveConfig writes configuration to a new yaml file
+func SaveConfig(filePath string, config Config) error {
 
-	if fileExists {
+	if FileFormat(filePath) != FormatYaml {
+		return ErrFormatNotYaml
+	}
+
+	if FileExists(filePath) {
 		return ErrGeneratorConfigFileAlreadyExists
 	}
 
 	createConfigDirectoryStructure(filePath)
 
-	configFile, err := os.OpenFile(filePath, os.O_CREATE|os.O_RDWR, 0755)
+	var configData bytes.Buffer
 
-	if err != nil {
-		return ErrGeneratorConfigFileError
+	if err := generateConfig(&configData, config); err != nil {
+		return err
 	}
 
-	defer configFile.Close()
-
-	generateClientConfigTemplate(configFile)
+	// configuration contains clockify token and jira passwords
+	if err := os.WriteFile(filePath, configData.Bytes(), 0600); err != nil {
+		return ErrGeneratorConfigFileError
+	}
 
 	return nil
 }
 
-func checkFileExists(filePath string) bool {
+func FileExists(filePath string) bool {
 	_, error := os.Stat(filePath)
 
 	return !errors.Is(error, os.ErrNotExist)
 }
 
-func generateClientConfigTemplate(writer io.Writer) {
-	yamlData, _ := yaml.Marshal(configTemplate)
+func generateClientConfigTemplate(writer io.Writer) error {
+	return generateConfig(writer, configTemplate)
+}
+
+func generateConfig(writer io.Writer, config Config) error {
+	yamlData, err := yaml.Marshal(config)
+
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrGeneratorConfigFileError, err)
+	}
 
-	writer.Write(yamlData)
+	if _, err := writer.Write(yamlData); err != nil {
+		return fmt.Errorf("%w: %v", ErrGeneratorConfigFileError, err)
+	}
+
+	return nil
 }
 
 func createConfigDirectoryStructure(filePath string) {
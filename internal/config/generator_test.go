@@ -17,9 +17,10 @@ func TestGenerateDefaultConfig(t *testing.T) {
 	t.Run("Generate default config template", func(t *testing.T) {
 		buffer := bytes.Buffer{}
 
-		generateClientConfigTemplate(&buffer)
+		err := generateClientConfigTemplate(&buffer)
 
-		want := `global:
+		want := `version: 1
+global:
   clockify_token: (visit https://app.clockify.me/user/preferences#advanced)
   period: 7
 default_client:
@@ -27,7 +28,9 @@ default_client:
   jira_host: https://headstart.atlassian.net
   jira_username: firstname.lastname@domain.com
   jira_password: (visit https://id.atlassian.com/manage/api-tokens)
+  jira_api_version: 2
   stachursky_mode: 15
+  rounding_strategy: nearest
   enabled: false
 default_workspace:
   workspace_id: (visit https://app.clockify.me/workspaces -> settings -> id from url)
@@ -38,6 +41,7 @@ default_workspace:
 workspaces: {}
 `
 
+		assert.Errors(t, err, nil)
 		assert.Strings(t, buffer.String(), want)
 	})
 }
@@ -65,6 +69,18 @@ func TestSaveConfigToYamlFile(t *testing.T) {
 		assert.FileExists(t, configFilePath)
 	})
 
+	t.Run("Write configuration readable only by the owner", func(t *testing.T) {
+		configFilePath := tmpComfigBasePath + "private-config.yaml"
+
+		defer os.RemoveAll(tmpComfigBasePath)
+
+		err := InitializeConfig(configFilePath)
+		info, _ := os.Stat(configFilePath)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, info.Mode().Perm().String(), "-rw-------")
+	})
+
 	t.Run("Throws error on empty file path", func(t *testing.T) {
 		configFilePath := ""
 		err := InitializeConfig(configFilePath)
@@ -84,4 +100,30 @@ func TestSaveConfigToYamlFile(t *testing.T) {
 
 		assert.Errors(t, err, ErrGeneratorConfigFileAlreadyExists)
 	})
+
+	t.Run("Write given configuration to yaml file", func(t *testing.T) {
+		configFilePath := tmpComfigBasePath + "wizard-config.yaml"
+		defer os.RemoveAll(tmpComfigBasePath)
+
+		workspaces := Workspaces{
+			"ws_1": &Workspace{
+				WorkspaceId: "ws-1",
+				Clients: Clients{
+					"client 1": &Client{Enabled: true, JiraHost: "https://domain.atlassian.net"},
+				},
+			},
+		}
+
+		err := SaveConfig(configFilePath, NewConfigFromTemplate("clockify-token", workspaces))
+
+		assert.Errors(t, err, nil)
+
+		config, err := LoadFromFile(configFilePath)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, config.Global.ClockifyToken, "clockify-token")
+		assert.Strings(t, config.Workspaces["ws_1"].WorkspaceId, "ws-1")
+		assert.Strings(t, config.Workspaces["ws_1"].JiraMigrationSuccessTag, "logged")
+		assert.Bools(t, config.Workspaces["ws_1"].Clients["client 1"].Enabled, true)
+	})
 }
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ErrInterpolationMissingVariable = ConfigErr("Missing environment variable")
+)
+
+// ${VAR} or ${VAR:-default}
+var interpolationPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// interpolate replaces environment variable references in all string values of decoded yaml data
+func interpolate(data interface{}, path string) (interface{}, error) {
+
+	switch value := data.(type) {
+	case yaml.MapSlice:
+		result := make(yaml.MapSlice, 0, len(value))
+
+		for _, item := range value {
+			interpolated, err := interpolate(item.Value, joinPath(path, fmt.Sprint(item.Key)))
+
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, yaml.MapItem{Key: item.Key, Value: interpolated})
+		}
+
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, 0, len(value))
+
+		for index, item := range value {
+			interpolated, err := interpolate(item, fmt.Sprintf("%s[%d]", path, index))
+
+			if err != nil {
+				return nil, err
+			}
+
+			result = append(result, interpolated)
+		}
+
+		return result, nil
+	case string:
+		return interpolateString(value, path)
+	}
+
+	return data, nil
+}
+
+func interpolateString(value, path string) (string, error) {
+
+	var err error
+
+	result := interpolationPattern.ReplaceAllStringFunc(value, func(reference string) string {
+
+		match := interpolationPattern.FindStringSubmatch(reference)
+		variable, hasDefault, defaultValue := match[1], match[2] != "", match[3]
+
+		if envValue, ok := os.LookupEnv(variable); ok && (envValue != "" || !hasDefault) {
+			return envValue
+		}
+
+		if hasDefault {
+			return defaultValue
+		}
+
+		if err == nil {
+			err = fmt.Errorf("%w %s used in %s", ErrInterpolationMissingVariable, variable, path)
+		}
+
+		return reference
+	})
+
+	return result, err
+}
+
+func joinPath(path, key string) string {
+
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
@@ -0,0 +1,342 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	ProjectConfigFileName = ".clockify-to-jira"
+
+	ErrLayerIncludeNotFound   = ConfigErr("Cannot load included configuration file")
+	ErrLayerIncludeCycle      = ConfigErr("Configuration files include each other")
+	ErrLayerInvalidInclude    = ConfigErr("Include has to be a list of file paths")
+	ErrLayerConflict          = ConfigErr("Configuration files conflict")
+	ErrLayerProjectNotTrusted = ConfigErr("Project configuration files cannot set credentials, secret commands or jira_host - move them to user configuration")
+
+	includeKey = "include"
+)
+
+var (
+	systemConfigPath = "/etc/clockify-to-jira/config.yaml"
+
+	// projectConfigExtensions are checked in order, the first existing project file is used
+	projectConfigExtensions = []string{".yaml", ".yml", ".json", ".toml"}
+
+	// projectForbiddenKeys cannot be set by project files (and their includes) found in the current directory,
+	// e.g. cloned repository could run commands or send jira credentials to its own host
+	projectForbiddenKeys = []string{"clockify_token", "clockify_token_command", "jira_username", "jira_password", "jira_password_command", "jira_host"}
+)
+
+// layer is a single configuration file migrated to the current layout
+type layer struct {
+	path          string
+	fileData      []byte
+	document      yaml.MapSlice
+	sourceVersion int
+}
+
+// findLayerPaths returns configuration files in merge order: system, user and the closest project file.
+// Only user configuration file is required.
+func findLayerPaths(userConfigPath, workingDir string) []string {
+
+	paths := []string{}
+
+	if FileExists(systemConfigPath) {
+		paths = append(paths, systemConfigPath)
+	}
+
+	paths = append(paths, userConfigPath)
+
+	if projectConfigPath := findProjectLayerPath(userConfigPath, workingDir); projectConfigPath != "" {
+		paths = append(paths, projectConfigPath)
+	}
+
+	return paths
+}
+
+// findProjectLayerPath returns the closest project file, user configuration file is never a project file
+func findProjectLayerPath(userConfigPath, workingDir string) string {
+
+	for dir := workingDir; dir != ""; dir = parentDir(dir) {
+		projectConfigPath, ok := findProjectConfig(dir)
+
+		if ok && !sameFile(projectConfigPath, userConfigPath) {
+			return projectConfigPath
+		}
+
+		if ok {
+			return ""
+		}
+	}
+
+	return ""
+}
+
+func findProjectConfig(dir string) (string, bool) {
+
+	for _, extension := range projectConfigExtensions {
+		projectConfigPath := filepath.Join(dir, ProjectConfigFileName+extension)
+
+		if FileExists(projectConfigPath) {
+			return projectConfigPath, true
+		}
+	}
+
+	return "", false
+}
+
+// loadLayers reads configuration files, included files go before the file including them.
+// Project file and its includes cannot set credentials, secret commands and jira hosts
+func loadLayers(paths []string, userConfigPath, projectPath string) ([]layer, error) {
+
+	layers := []layer{}
+
+	for _, path := range paths {
+		fileLayers, err := loadLayer(path, []string{}, path == userConfigPath)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if path == projectPath {
+			for index := range fileLayers {
+				if keyPaths := findKeys(fileLayers[index].document, "", projectForbiddenKeys); len(keyPaths) != 0 {
+					return nil, fmt.Errorf("%w: %s (%s)", ErrLayerProjectNotTrusted, strings.Join(keyPaths, ", "), fileLayers[index].path)
+				}
+			}
+		}
+
+		layers = append(layers, fileLayers...)
+	}
+
+	return layers, nil
+}
+
+// findKeys returns paths of given keys set anywhere in the document
+func findKeys(document yaml.MapSlice, path string, keys []string) []string {
+
+	found := []string{}
+
+	for _, item := range document {
+		key := fmt.Sprint(item.Key)
+		itemPath := joinPath(path, key)
+
+		if slices.Contains(keys, key) && item.Value != nil {
+			found = append(found, itemPath)
+		}
+
+		if mapping, ok := item.Value.(yaml.MapSlice); ok {
+			found = append(found, findKeys(mapping, itemPath, keys)...)
+		}
+	}
+
+	return found
+}
+
+// loadLayer loads file with its includes, only the user configuration file can have the single workspace layout
+// used before 1.0.0 - system, project and included files often hold client profiles only
+func loadLayer(path string, including []string, userConfig bool) ([]layer, error) {
+
+	absolutePath, _ := filepath.Abs(path)
+
+	if slices.Contains(including, absolutePath) {
+		return nil, fmt.Errorf("%w - %s", ErrLayerIncludeCycle, strings.Join(append(including, absolutePath), " -> "))
+	}
+
+	fileData, err := createFileConfigSource(path)
+
+	if err != nil {
+		if len(including) != 0 {
+			return nil, fmt.Errorf("%w - %s (included from %s)", ErrLayerIncludeNotFound, path, including[len(including)-1])
+		}
+
+		return nil, err
+	}
+
+	document, err := decodeFile(path, fileData)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w (%s)", err, path)
+	}
+
+	includes, err := getIncludes(document, path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	migrate := migrateIncludedDocument
+
+	if userConfig {
+		migrate = migrateDocument
+	}
+
+	document, sourceVersion, err := migrate(deleteKey(document, includeKey))
+
+	if err != nil {
+		return nil, fmt.Errorf("%w (%s)", err, path)
+	}
+
+	layers := []layer{}
+
+	for _, include := range includes {
+		includedLayers, err := loadLayer(include, append(slices.Clone(including), absolutePath), false)
+
+		if err != nil {
+			return nil, err
+		}
+
+		layers = append(layers, includedLayers...)
+	}
+
+	return append(layers, layer{path: path, fileData: fileData, document: document, sourceVersion: sourceVersion}), nil
+}
+
+// getIncludes returns included file paths relative to the including file
+func getIncludes(document yaml.MapSlice, path string) ([]string, error) {
+
+	value, ok := getValue(document, includeKey)
+
+	if !ok || value == nil {
+		return nil, nil
+	}
+
+	items, ok := value.([]interface{})
+
+	if !ok {
+		return nil, fmt.Errorf("%w (%s)", ErrLayerInvalidInclude, path)
+	}
+
+	includes := []string{}
+
+	for _, item := range items {
+		include, ok := item.(string)
+
+		if !ok || include == "" {
+			return nil, fmt.Errorf("%w (%s)", ErrLayerInvalidInclude, path)
+		}
+
+		if strings.HasPrefix(include, "~") {
+			homeDir, _ := os.UserHomeDir()
+			include = strings.Replace(include, "~", homeDir, 1)
+		}
+
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(path), include)
+		}
+
+		includes = append(includes, include)
+	}
+
+	return includes, nil
+}
+
+// mergeLayers deep merges mappings, other values of later layers replace earlier ones
+func mergeLayers(layers []layer) (yaml.MapSlice, error) {
+
+	merged := yaml.MapSlice{}
+	origins := map[string]string{}
+
+	for _, layer := range layers {
+		var err error
+
+		merged, err = mergeDocuments(merged, layer.document, "", layer.path, origins)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return merged, nil
+}
+
+func mergeDocuments(target, source yaml.MapSlice, path, sourcePath string, origins map[string]string) (yaml.MapSlice, error) {
+
+	for _, item := range source {
+		key := fmt.Sprint(item.Key)
+		itemPath := joinPath(path, key)
+		current, exists := getValue(target, key)
+
+		currentMapping, currentIsMapping := current.(yaml.MapSlice)
+		sourceMapping, sourceIsMapping := item.Value.(yaml.MapSlice)
+
+		switch {
+		case !exists || current == nil || item.Value == nil:
+			if item.Value == nil && exists {
+				continue
+			}
+
+			target = setValue(target, key, item.Value)
+		case currentIsMapping && sourceIsMapping:
+			mergedMapping, err := mergeDocuments(slices.Clone(currentMapping), sourceMapping, itemPath, sourcePath, origins)
+
+			if err != nil {
+				return nil, err
+			}
+
+			target = setValue(target, key, mergedMapping)
+		case currentIsMapping != sourceIsMapping:
+			return nil, fmt.Errorf("%w - %s is a mapping in one file and a value in the other (%s, %s)", ErrLayerConflict, itemPath, originOf(origins, itemPath), sourcePath)
+		default:
+			target = setValue(target, key, item.Value)
+		}
+
+		origins[itemPath] = sourcePath
+	}
+
+	return target, nil
+}
+
+// originOf returns file which set the path or its closest parent
+func originOf(origins map[string]string, path string) string {
+
+	for {
+		if origin, ok := origins[path]; ok {
+			return origin
+		}
+
+		index := strings.LastIndex(path, ".")
+
+		if index == -1 {
+			return ""
+		}
+
+		path = path[:index]
+	}
+}
+
+func layerPaths(layers []layer) []string {
+
+	paths := make([]string, 0, len(layers))
+
+	for _, layer := range layers {
+		paths = append(paths, layer.path)
+	}
+
+	return paths
+}
+
+func parentDir(dir string) string {
+
+	parent := filepath.Dir(dir)
+
+	if parent == dir {
+		return ""
+	}
+
+	return parent
+}
+
+func sameFile(a, b string) bool {
+
+	aInfo, aErr := os.Stat(a)
+	bInfo, bErr := os.Stat(b)
+
+	return aErr == nil && bErr == nil && os.SameFile(aInfo, bInfo)
+}
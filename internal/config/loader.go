@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 
 	"gopkg.in/yaml.v2"
@@ -11,34 +12,114 @@ const (
 	ErrLoaderInvalidConfiguration = ConfigErr("Invalid config data - Unmarshall error")
 )
 
-func LoadFromYamlFile(filePath string) (Config, error) {
-	fileConfigData, err := createFileConfigSource(filePath)
+// LoadFromFile loads and merges system, user (filePath) and project configuration files.
+// Format of every file (yaml, json or toml) is chosen by its extension.
+func LoadFromFile(filePath string) (Config, error) {
+
+	config, layers, typeProblems, err := loadLayeredConfig(filePath)
 
 	if err != nil {
-		return Config{}, ErrLoaderConfigFileNotFound
+		return Config{}, err
 	}
 
-	config, err := Load(fileConfigData)
+	if len(typeProblems) != 0 {
+		return Config{}, fmt.Errorf("%w:\n%s", ErrLoaderInvalidConfiguration, formatProblems(typeProblems))
+	}
 
-	if err != nil {
-		return Config{}, ErrLoaderInvalidConfiguration
+	problems := locateLayerProblems(config.Validate(), layers)
+
+	if len(problems) != 0 {
+		return Config{}, fmt.Errorf("%w:\n%s", ErrValidationFailed, formatProblems(problems))
 	}
 
 	return config, nil
 }
 
+// loadLayeredConfig merges configuration files, values which don't match type of their setting are returned as problems of their files
+func loadLayeredConfig(filePath string) (Config, []layer, []Problem, error) {
+
+	workingDir, _ := os.Getwd()
+
+	layers, err := loadLayers(findLayerPaths(filePath, workingDir), filePath, findProjectLayerPath(filePath, workingDir))
+
+	if err != nil {
+		return Config{}, nil, nil, err
+	}
+
+	if typeProblems := checkLayerTypes(layers); len(typeProblems) != 0 {
+		return Config{}, layers, typeProblems, nil
+	}
+
+	document, err := mergeLayers(layers)
+
+	if err != nil {
+		return Config{}, nil, nil, err
+	}
+
+	sourceVersion := CurrentVersion
+
+	for _, layer := range layers {
+		sourceVersion = min(sourceVersion, layer.sourceVersion)
+	}
+
+	config, err := loadDocument(document, sourceVersion)
+
+	if err != nil {
+		return Config{}, nil, nil, err
+	}
+
+	config.Files = layerPaths(layers)
+
+	return config, layers, nil, nil
+}
+
 func Load(configDataProvider []byte) (Config, error) {
 
-	yamlData := configDataProvider
+	document, err := decodeDocument(configDataProvider)
+
+	if err != nil {
+		return Config{}, err
+	}
+
+	document, sourceVersion, err := migrateDocument(document)
+
+	if err != nil {
+		return Config{}, err
+	}
+
+	typeProblems := checkLayerTypes([]layer{{fileData: configDataProvider, document: document, sourceVersion: sourceVersion}})
+
+	if len(typeProblems) != 0 {
+		return Config{}, fmt.Errorf("%w:\n%s", ErrLoaderInvalidConfiguration, formatProblems(typeProblems))
+	}
+
+	return loadDocument(document, sourceVersion)
+}
+
+func loadDocument(document yaml.MapSlice, sourceVersion int) (Config, error) {
+
+	interpolated, err := interpolate(document, "")
+
+	if err != nil {
+		return Config{}, err
+	}
+
+	yamlData, err := yaml.Marshal(interpolated)
+
+	if err != nil {
+		return Config{}, fmt.Errorf("%w: %v", ErrLoaderInvalidConfiguration, err)
+	}
 
 	config := Config{}
 
-	err := yaml.Unmarshal(yamlData, &config)
+	err = yaml.Unmarshal(yamlData, &config)
 
 	if err != nil {
-		return Config{}, ErrLoaderInvalidConfiguration
+		return Config{}, fmt.Errorf("%w: %v", ErrLoaderInvalidConfiguration, err)
 	}
 
+	config.SourceVersion = sourceVersion
+	config.Global.sources = config.Global.keys.sources(SourceGlobal, nil)
 	config.Workspaces = config.combineWithDefaultConfig()
 
 	return config, nil
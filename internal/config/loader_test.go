@@ -9,12 +9,18 @@ import (
 func TestLoadConfigFromYamlFile(t *testing.T) {
 
 	t.Run("Load from yaml file", func(t *testing.T) {
-		config, err := LoadFromYamlFile("./examples/config.yaml")
+		config, err := LoadFromFile("./examples/config.yaml")
 		assert.Errors(t, err, nil)
 
+		assert.Ints(t, config.Version, 1)
+		assert.Ints(t, config.SourceVersion, 1)
+
 		global := config.Global
 		assert.Strings(t, global.ClockifyToken, "clockify-token")
 		assert.Ints(t, global.Period, 1)
+		assert.Ints(t, global.DailyCap, 600)
+		assert.Ints(t, global.WeeklyCap, 0)
+		assert.Strings(t, global.CapAction, "warn")
 
 		defaultClient := config.DefaultClient
 		assert.Strings(t, defaultClient.JiraClientUser, "firstname.lastname")
@@ -44,7 +50,16 @@ func TestLoadConfigFromYamlFile(t *testing.T) {
 		assert.Strings(t, ws1Client1.JiraHost, "https://domain.atlassian.net")
 		assert.Strings(t, ws1Client1.JiraPassword, "jirapassword-client-1")
 		assert.Strings(t, ws1Client1.JiraUsername, "username@domain.com")
+		assert.Ints(t, ws1Client1.JiraApiVersion, 3)
 		assert.Ints(t, ws1Client1.StachurskyMode, 30)
+		assert.Strings(t, ws1Client1.RoundingStrategy, "grace")
+		assert.Ints(t, ws1Client1.RoundingGrace, 5)
+		assert.Ints(t, ws1Client1.RoundingMinimum, 15)
+		assert.Ints(t, ws1Client1.MinDuration, 60)
+		assert.Strings(t, ws1Client1.MinDurationAction, "merge")
+		assert.Ints(t, ws1Client1.DailyCap, 480)
+		assert.Ints(t, ws1Client1.WeeklyCap, 2400)
+		assert.Strings(t, ws1Client1.CapAction, "scale")
 
 		ws1Client2 := ws1.Clients["client_2"]
 		assert.Bools(t, ws1Client2.Enabled, false)
@@ -52,10 +67,13 @@ func TestLoadConfigFromYamlFile(t *testing.T) {
 		assert.Strings(t, ws1Client2.JiraHost, "https://jira.atlassian.net")
 		assert.Strings(t, ws1Client2.JiraPassword, "jira-password")
 		assert.Strings(t, ws1Client2.JiraUsername, "firstname.lastname@domain.io")
+		assert.Ints(t, ws1Client2.JiraApiVersion, 0)
 		assert.Ints(t, ws1Client2.StachurskyMode, 15)
+		assert.Strings(t, ws1Client2.RoundingStrategy, "nearest")
+		assert.Ints(t, ws1Client2.RoundingGrace, 0)
 
 		ws2 := workspaces["ws_2"]
-		assert.Ints(t, len(ws2.Clients), 1)
+		assert.Ints(t, len(ws2.Clients), 2)
 		assert.Strings(t, ws2.WorkspaceId, "ws-2")
 		assert.Strings(t, ws2.JiraMigrationFailedTag, "failed")
 		assert.Strings(t, ws2.JiraMigrationSkipTag, "skipped")
@@ -67,19 +85,31 @@ func TestLoadConfigFromYamlFile(t *testing.T) {
 		assert.Strings(t, ws2Client3.JiraHost, "https://jira.atlassian.net")
 		assert.Strings(t, ws2Client3.JiraPassword, "jirapassword-client-3")
 		assert.Strings(t, ws2Client3.JiraUsername, "username3@domain.com")
+		assert.Strings(t, ws2Client3.Aggregation, "issue_day")
+		assert.Ints(t, ws2Client3.MergeGap, 5)
 		assert.Ints(t, ws2Client3.StachurskyMode, 15)
+
+		ws2Acme := ws2.Clients["acme"]
+		assert.Strings(t, ws2Acme.Profile, "acme")
+		assert.Bools(t, ws2Acme.Enabled, false)
+		assert.Strings(t, ws2Acme.JiraClientUser, "firstname.lastname")
+		assert.Strings(t, ws2Acme.JiraHost, "https://acme.atlassian.net")
+		assert.Strings(t, ws2Acme.JiraPassword, "jirapassword-acme")
+		assert.Strings(t, ws2Acme.JiraUsername, "username@acme.com")
+		assert.Ints(t, ws2Acme.StachurskyMode, 30)
+		assert.Ints(t, ws2Acme.MergeGap, 10)
 	})
 
 	t.Run("Throw error if file not found", func(t *testing.T) {
-		_, err := LoadFromYamlFile("./invalid-path")
+		_, err := LoadFromFile("./invalid-path")
 
 		assert.Errors(t, err, ErrLoaderConfigFileNotFound)
 	})
 
 	t.Run("Throw error on invalid config", func(t *testing.T) {
-		_, err := LoadFromYamlFile("./examples/invalid-config.yaml")
+		_, err := LoadFromFile("./examples/invalid-config.yaml")
 
-		assert.Errors(t, err, ErrLoaderInvalidConfiguration)
+		assert.ErrorsIs(t, err, ErrLoaderInvalidConfiguration)
 	})
 }
 
@@ -111,6 +141,53 @@ global:
 
 		_, err := Load(invalidInlineConfig)
 
-		assert.Errors(t, err, ErrLoaderInvalidConfiguration)
+		assert.ErrorsIs(t, err, ErrLoaderInvalidConfiguration)
+	})
+}
+
+func TestLoadConfigWithEnvironmentVariables(t *testing.T) {
+
+	inlineConfig := []byte(`global:
+  clockify_token: ${CTJ_TEST_CLOCKIFY_TOKEN}
+  period: 9
+workspaces:
+  ws_1:
+    workspace_id: ${CTJ_TEST_WORKSPACE_ID:-ws-1}
+    clients:
+      client_1:
+        jira_password: pass-${CTJ_TEST_JIRA_PASSWORD}-word
+`)
+
+	t.Run("Interpolate environment variables", func(t *testing.T) {
+		t.Setenv("CTJ_TEST_CLOCKIFY_TOKEN", "env-clockify-token")
+		t.Setenv("CTJ_TEST_JIRA_PASSWORD", "secret")
+
+		config, err := Load(inlineConfig)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, config.Global.ClockifyToken, "env-clockify-token")
+		assert.Ints(t, config.Global.Period, 9)
+		assert.Strings(t, config.Workspaces["ws_1"].WorkspaceId, "ws-1")
+		assert.Strings(t, config.Workspaces["ws_1"].Clients["client_1"].JiraPassword, "pass-secret-word")
+	})
+
+	t.Run("Use default value for empty variable", func(t *testing.T) {
+		t.Setenv("CTJ_TEST_CLOCKIFY_TOKEN", "env-clockify-token")
+		t.Setenv("CTJ_TEST_JIRA_PASSWORD", "secret")
+		t.Setenv("CTJ_TEST_WORKSPACE_ID", "")
+
+		config, err := Load(inlineConfig)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, config.Workspaces["ws_1"].WorkspaceId, "ws-1")
+	})
+
+	t.Run("Throw error with config path on missing variable", func(t *testing.T) {
+		t.Setenv("CTJ_TEST_CLOCKIFY_TOKEN", "env-clockify-token")
+
+		_, err := Load(inlineConfig)
+
+		assert.ErrorsIs(t, err, ErrInterpolationMissingVariable)
+		assert.Strings(t, err.Error(), "Missing environment variable CTJ_TEST_JIRA_PASSWORD used in workspaces.ws_1.clients.client_1.jira_password")
 	})
 }
@@ -0,0 +1,257 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// CurrentVersion is the configuration layout version written by this release
+	CurrentVersion = 1
+
+	ErrMigrationUnsupportedVersion = ConfigErr("Configuration version is newer than supported - upgrade clockify-to-jira")
+	ErrMigrationInvalidVersion     = ConfigErr("Configuration version has to be a number")
+	ErrMigrationBackupFailed       = ConfigErr("Cannot create configuration backup")
+	ErrMigrationWriteFailed        = ConfigErr("Cannot write migrated configuration")
+
+	backupTimeFormat = "20060102-150405"
+	legacyWorkspace  = "default"
+)
+
+// migrations[n] upgrades layout version n to n+1
+var migrations = []func(document yaml.MapSlice) yaml.MapSlice{
+	migrateSingleWorkspaceLayout,
+}
+
+// MigrateYaml upgrades configuration to the current layout and returns its source version
+func MigrateYaml(yamlData []byte) ([]byte, int, error) {
+
+	document, err := decodeDocument(yamlData)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return migrateYamlDocument(document)
+}
+
+func migrateYamlDocument(document yaml.MapSlice) ([]byte, int, error) {
+
+	migrated, sourceVersion, err := migrateDocument(document)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	migratedYaml, err := yaml.Marshal(migrated)
+
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", ErrLoaderInvalidConfiguration, err)
+	}
+
+	return migratedYaml, sourceVersion, nil
+}
+
+// MigrateFile upgrades configuration file of any supported format, migrated configuration is returned as yaml
+func MigrateFile(filePath string) ([]byte, int, error) {
+
+	fileData, err := createFileConfigSource(filePath)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	document, err := decodeFile(filePath, fileData)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return migrateYamlDocument(document)
+}
+
+// MigrateYamlFile rewrites configuration file in the current layout, the original file is kept as backup.
+// Empty backup path means the file already uses the current layout and has version key.
+func MigrateYamlFile(filePath string, now time.Time) (string, error) {
+
+	if FileFormat(filePath) != FormatYaml {
+		return "", ErrFormatNotYaml
+	}
+
+	yamlData, err := createFileConfigSource(filePath)
+
+	if err != nil {
+		return "", err
+	}
+
+	migratedYaml, sourceVersion, err := MigrateYaml(yamlData)
+
+	if err != nil {
+		return "", err
+	}
+
+	document, _ := decodeDocument(yamlData)
+	_, versioned := getValue(document, "version")
+
+	if sourceVersion == CurrentVersion && versioned {
+		return "", nil
+	}
+
+	backupPath := fmt.Sprintf("%s.%s.bak", filePath, now.Format(backupTimeFormat))
+
+	err = os.WriteFile(backupPath, yamlData, 0600)
+
+	if err != nil {
+		return "", ErrMigrationBackupFailed
+	}
+
+	err = os.WriteFile(filePath, migratedYaml, 0600)
+
+	if err != nil {
+		return "", ErrMigrationWriteFailed
+	}
+
+	return backupPath, nil
+}
+
+func decodeDocument(yamlData []byte) (yaml.MapSlice, error) {
+
+	document := yaml.MapSlice{}
+
+	err := yaml.Unmarshal(yamlData, &document)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrLoaderInvalidConfiguration, err)
+	}
+
+	return document, nil
+}
+
+func migrateDocument(document yaml.MapSlice) (yaml.MapSlice, int, error) {
+	return migrateDocumentLayout(document, true)
+}
+
+// migrateIncludedDocument never reads system, project and included files as the single workspace layout - they often hold client profiles only
+func migrateIncludedDocument(document yaml.MapSlice) (yaml.MapSlice, int, error) {
+	return migrateDocumentLayout(document, false)
+}
+
+func migrateDocumentLayout(document yaml.MapSlice, root bool) (yaml.MapSlice, int, error) {
+
+	sourceVersion, err := detectVersion(document, root)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for version := sourceVersion; version < CurrentVersion; version++ {
+		document = migrations[version](document)
+	}
+
+	document = setValue(deleteKey(document, "version"), "version", CurrentVersion)
+
+	// version is the first key of migrated files
+	document = append(document[len(document)-1:], document[:len(document)-1]...)
+
+	return document, sourceVersion, nil
+}
+
+// detectVersion treats files without version key as the 1.0.0 layout unless the user configuration file uses the single workspace layout
+// (top level clients without workspaces - top level clients next to workspaces are client profiles)
+func detectVersion(document yaml.MapSlice, root bool) (int, error) {
+
+	version, ok := getValue(document, "version")
+
+	if !ok && !root {
+		return CurrentVersion, nil
+	}
+
+	if !ok {
+		_, hasClients := getValue(document, "clients")
+		_, hasWorkspaces := getValue(document, "workspaces")
+
+		if hasClients && !hasWorkspaces {
+			return 0, nil
+		}
+
+		return CurrentVersion, nil
+	}
+
+	number, ok := version.(int)
+
+	if !ok || number < 0 {
+		return 0, ErrMigrationInvalidVersion
+	}
+
+	if number > CurrentVersion {
+		return 0, ErrMigrationUnsupportedVersion
+	}
+
+	return number, nil
+}
+
+// migrateSingleWorkspaceLayout moves workspace settings from global and top level clients
+// of the layout used before 1.0.0 into the "default" workspace
+func migrateSingleWorkspaceLayout(document yaml.MapSlice) yaml.MapSlice {
+
+	workspace := yaml.MapSlice{}
+	global, _ := getValue(document, "global")
+	globalSettings, _ := global.(yaml.MapSlice)
+
+	for _, key := range []string{"workspace_id", "jira_migration_failed_tag", "jira_migration_skip_tag", "jira_migration_success_tag"} {
+		if value, ok := getValue(globalSettings, key); ok {
+			workspace = setValue(workspace, key, value)
+			globalSettings = deleteKey(globalSettings, key)
+		}
+	}
+
+	if clients, ok := getValue(document, "clients"); ok {
+		workspace = setValue(workspace, "clients", clients)
+		document = deleteKey(document, "clients")
+	}
+
+	if globalSettings != nil {
+		document = setValue(document, "global", globalSettings)
+	}
+
+	return setValue(document, "workspaces", yaml.MapSlice{{Key: legacyWorkspace, Value: workspace}})
+}
+
+func getValue(document yaml.MapSlice, key string) (interface{}, bool) {
+
+	for _, item := range document {
+		if item.Key == key {
+			return item.Value, true
+		}
+	}
+
+	return nil, false
+}
+
+func setValue(document yaml.MapSlice, key string, value interface{}) yaml.MapSlice {
+
+	for index, item := range document {
+		if item.Key == key {
+			document[index].Value = value
+			return document
+		}
+	}
+
+	return append(document, yaml.MapItem{Key: key, Value: value})
+}
+
+func deleteKey(document yaml.MapSlice, key string) yaml.MapSlice {
+
+	result := yaml.MapSlice{}
+
+	for _, item := range document {
+		if item.Key != key {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+const (
+	legacyConfig = `global:
+  clockify_token: clockify-token
+  period: 1
+  workspace_id: ws-1
+  jira_migration_success_tag: logged
+default_client:
+  jira_host: https://jira.atlassian.net
+clients:
+  client_1:
+    enabled: true
+`
+	migratedLegacyConfig = `version: 1
+global:
+  clockify_token: clockify-token
+  period: 1
+default_client:
+  jira_host: https://jira.atlassian.net
+workspaces:
+  default:
+    workspace_id: ws-1
+    jira_migration_success_tag: logged
+    clients:
+      client_1:
+        enabled: true
+`
+)
+
+func TestMigrateYaml(t *testing.T) {
+
+	t.Run("Migrate single workspace layout", func(t *testing.T) {
+		got, sourceVersion, err := MigrateYaml([]byte(legacyConfig))
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, sourceVersion, 0)
+		assert.Strings(t, string(got), migratedLegacyConfig)
+	})
+
+	t.Run("Add version to current layout", func(t *testing.T) {
+		got, sourceVersion, err := MigrateYaml([]byte("global:\n  period: 1\nworkspaces: {}\n"))
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, sourceVersion, CurrentVersion)
+		assert.Strings(t, string(got), "version: 1\nglobal:\n  period: 1\nworkspaces: {}\n")
+	})
+
+	t.Run("Keep client profiles of current layout", func(t *testing.T) {
+		got, sourceVersion, err := MigrateYaml([]byte("clients: {}\nworkspaces: {}\n"))
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, sourceVersion, CurrentVersion)
+		assert.Strings(t, string(got), "version: 1\nclients: {}\nworkspaces: {}\n")
+	})
+
+	t.Run("Keep environment variable references", func(t *testing.T) {
+		got, _, err := MigrateYaml([]byte("clients: {}\nglobal:\n  clockify_token: ${CLOCKIFY_TOKEN}\n"))
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, string(got), "version: 1\nglobal:\n  clockify_token: ${CLOCKIFY_TOKEN}\nworkspaces:\n  default:\n    clients: {}\n")
+	})
+
+	t.Run("Throw error on newer version", func(t *testing.T) {
+		_, _, err := MigrateYaml([]byte("version: 99\n"))
+
+		assert.Errors(t, err, ErrMigrationUnsupportedVersion)
+	})
+
+	t.Run("Throw error on invalid version", func(t *testing.T) {
+		_, _, err := MigrateYaml([]byte("version: latest\n"))
+
+		assert.Errors(t, err, ErrMigrationInvalidVersion)
+	})
+
+	t.Run("Load single workspace layout", func(t *testing.T) {
+		config, err := Load([]byte(legacyConfig))
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, config.Version, CurrentVersion)
+		assert.Ints(t, config.SourceVersion, 0)
+		assert.Strings(t, config.Workspaces["default"].WorkspaceId, "ws-1")
+		assert.Strings(t, config.Workspaces["default"].Clients["client_1"].JiraHost, "https://jira.atlassian.net")
+	})
+}
+
+func TestMigrateYamlFile(t *testing.T) {
+
+	now := time.Date(2025, time.January, 8, 10, 0, 0, 0, time.Local)
+
+	t.Run("Rewrite file and keep backup", func(t *testing.T) {
+		configFilePath := tmpComfigBasePath + "legacy-config.yaml"
+		defer os.RemoveAll(tmpComfigBasePath)
+
+		os.MkdirAll(tmpComfigBasePath, 0755)
+		os.WriteFile(configFilePath, []byte(legacyConfig), 0600)
+
+		backupPath, err := MigrateYamlFile(configFilePath, now)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, backupPath, configFilePath+".20250108-100000.bak")
+
+		backup, _ := os.ReadFile(backupPath)
+		migrated, _ := os.ReadFile(configFilePath)
+
+		assert.Strings(t, string(backup), legacyConfig)
+		assert.Strings(t, string(migrated), migratedLegacyConfig)
+	})
+
+	t.Run("Add version key to file in current layout", func(t *testing.T) {
+		configFilePath := tmpComfigBasePath + "unversioned-config.yaml"
+		defer os.RemoveAll(tmpComfigBasePath)
+
+		os.MkdirAll(tmpComfigBasePath, 0755)
+		os.WriteFile(configFilePath, []byte("global:\n  period: 1\n"), 0600)
+
+		backupPath, err := MigrateYamlFile(configFilePath, now)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, backupPath, configFilePath+".20250108-100000.bak")
+
+		migrated, _ := os.ReadFile(configFilePath)
+
+		assert.Strings(t, string(migrated), "version: 1\nglobal:\n  period: 1\n")
+	})
+
+	t.Run("Skip file in current layout", func(t *testing.T) {
+		configFilePath := tmpComfigBasePath + "current-config.yaml"
+		defer os.RemoveAll(tmpComfigBasePath)
+
+		os.MkdirAll(tmpComfigBasePath, 0755)
+		os.WriteFile(configFilePath, []byte(migratedLegacyConfig), 0600)
+
+		backupPath, err := MigrateYamlFile(configFilePath, now)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, backupPath, "")
+	})
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	SourceDefault   = "default"
+	SourceGlobal    = "global"
+	SourceWorkspace = "workspace"
+	SourceProfile   = "profile"
+	SourceClient    = "client"
+	SourceFlag      = "flag"
+)
+
+// presence holds keys explicitly set in yaml, so false, 0 and "" can override defaults
+type presence map[string]bool
+
+// sources maps setting key to the layer it comes from
+type sources map[string]string
+
+func (c *Client) UnmarshalYAML(unmarshal func(interface{}) error) error {
+
+	type plain Client
+
+	err := unmarshal((*plain)(c))
+
+	if err != nil {
+		return err
+	}
+
+	c.keys, err = readPresence(unmarshal)
+
+	return err
+}
+
+func (w *Workspace) UnmarshalYAML(unmarshal func(interface{}) error) error {
+
+	type plain Workspace
+
+	err := unmarshal((*plain)(w))
+
+	if err != nil {
+		return err
+	}
+
+	w.keys, err = readPresence(unmarshal)
+
+	return err
+}
+
+func (g *Global) UnmarshalYAML(unmarshal func(interface{}) error) error {
+
+	type plain Global
+
+	err := unmarshal((*plain)(g))
+
+	if err != nil {
+		return err
+	}
+
+	g.keys, err = readPresence(unmarshal)
+
+	return err
+}
+
+func readPresence(unmarshal func(interface{}) error) (presence, error) {
+
+	document := yaml.MapSlice{}
+
+	err := unmarshal(&document)
+
+	if err != nil {
+		return nil, err
+	}
+
+	keys := presence{}
+
+	for _, item := range document {
+		keys[fmt.Sprint(item.Key)] = true
+	}
+
+	return keys, nil
+}
+
+// overrides tells if setting was explicitly set or has non zero value (configuration built in code)
+func (p presence) overrides(key string, nonZero bool) bool {
+	return nonZero || p[key]
+}
+
+func (p presence) sources(source string, result sources) sources {
+
+	if result == nil {
+		result = sources{}
+	}
+
+	for key := range p {
+		result[key] = source
+	}
+
+	return result
+}
+
+func (s sources) set(key, source string) sources {
+
+	if s == nil {
+		s = sources{}
+	}
+
+	s[key] = source
+
+	return s
+}
+
+func (s sources) has(key string) bool {
+
+	_, ok := s[key]
+
+	return ok
+}
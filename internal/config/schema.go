@@ -0,0 +1,182 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/kruc/clockify-to-jira/internal/rounding"
+)
+
+const (
+	schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+)
+
+// schema is a subset of JSON Schema used to describe configuration
+type schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	Defs                 map[string]*schema `json:"$defs,omitempty"`
+}
+
+var (
+	schemaDescriptions = map[string]string{
+		"version":                    "Configuration layout version",
+		"include":                    "Configuration files merged before this file, paths are relative to this file",
+		"global":                     "Settings shared by all workspaces",
+		"default_client":             "Settings inherited by every client",
+		"default_workspace":          "Settings inherited by every workspace",
+		"workspaces":                 "Clockify workspaces",
+		"clients":                    "Clients of the workspace",
+		"clockify_token":             "Clockify API token",
+		"clockify_token_command":     "Shell command printing clockify API token",
+		"period":                     "Migrate time entries from last given days",
+		"daily_cap":                  "Maximum rounded time logged per day (minutes)",
+		"weekly_cap":                 "Maximum rounded time logged per week (minutes)",
+		"cap_action":                 "Action taken when daily or weekly cap is exceeded",
+		"timezone":                   "IANA timezone of calendar days, e.g. Europe/Warsaw (local by default)",
+		"week_start":                 "First day of this-week and last-week date ranges",
+		"retry_limit":                "Failed migration attempts after which --retry-failed no longer retries time entry (default 3)",
+		"workspace_id":               "Clockify workspace id",
+		"jira_migration_failed_tag":  "Clockify tag of time entries which failed to migrate",
+		"jira_migration_skip_tag":    "Clockify tag of time entries which are never migrated",
+		"jira_migration_success_tag": "Clockify tag of migrated time entries",
+		"clockify_client_id":         "Clockify client id, matched before client name",
+		"aliases":                    "Other clockify client names of this client",
+		"profile":                    "Client profile from top level clients",
+		"jira_client_user":           "Jira user of the client",
+		"jira_host":                  "Jira url, e.g. https://domain.atlassian.net",
+		"jira_username":              "Jira username",
+		"jira_password":              "Jira password or API token",
+		"jira_password_command":      "Shell command printing jira password",
+		"jira_api_version":           "Jira REST API version - 2 (Server / Data Center) or 3 (Cloud)",
+		"jira_projects":              "Jira project keys the client logs work to, e.g. ABC (all projects by default)",
+		"stachursky_mode":            "Rounding precision (minutes)",
+		"rounding_strategy":          "How time is rounded to stachursky_mode",
+		"rounding_grace":             "Remainder (minutes) rounded down by grace strategy",
+		"rounding_minimum":           "Smallest time logged for a single worklog (minutes)",
+		"aggregation":                "Worklog aggregation - none or one worklog per issue and day",
+		"merge_gap":                  "Merge adjacent time entries of the same issue separated by less than given minutes",
+		"min_duration":               "Time entries shorter than given seconds are handled by min_duration_action",
+		"min_duration_action":        "Action taken for time entries shorter than min_duration",
+		"enabled":                    "Migrate time entries of the client",
+	}
+
+	schemaEnums = map[string][]interface{}{
+		"jira_api_version":    enumValues(jiraApiVersions[1:]),
+		"rounding_strategy":   enumValues(rounding.Strategies),
+		"aggregation":         enumValues(aggregations[1:]),
+		"min_duration_action": enumValues(minDurationActions[1:]),
+		"cap_action":          enumValues(capActions[1:]),
+		"week_start":          enumValues(weekdays),
+	}
+
+	// schemaMinimums override minimum 0 of integer settings
+	schemaMinimums = map[string]int{
+		"stachursky_mode": 1,
+	}
+
+	// schemaDefs are types referenced by several properties
+	schemaDefs = map[reflect.Type]string{
+		reflect.TypeOf(Client{}):    "client",
+		reflect.TypeOf(Workspace{}): "workspace",
+	}
+)
+
+// Schema returns JSON Schema of the configuration file generated from Config
+func Schema() ([]byte, error) {
+
+	root := structSchema(reflect.TypeOf(Config{}))
+	root.Schema = schemaDraft
+	root.Title = "clockify-to-jira configuration"
+	root.Properties[includeKey] = &schema{
+		Description: schemaDescriptions[includeKey],
+		Type:        "array",
+		Items:       &schema{Type: "string"},
+	}
+	root.Properties["clients"].Description = "Client profiles referenced by workspace clients with profile key"
+	root.Defs = map[string]*schema{}
+
+	for defType, name := range schemaDefs {
+		root.Defs[name] = structSchema(defType)
+	}
+
+	return json.MarshalIndent(root, "", "  ")
+}
+
+func structSchema(structType reflect.Type) *schema {
+
+	result := &schema{
+		Type:                 "object",
+		Properties:           map[string]*schema{},
+		AdditionalProperties: false,
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+
+		if !field.IsExported() || key == "" || key == "-" {
+			continue
+		}
+
+		property := typeSchema(field.Type)
+		property.Description = schemaDescriptions[key]
+		property.Enum = schemaEnums[key]
+
+		if minimum, ok := schemaMinimums[key]; ok {
+			property.Minimum = &minimum
+		}
+
+		result.Properties[key] = property
+	}
+
+	return result
+}
+
+func typeSchema(fieldType reflect.Type) *schema {
+
+	if fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+
+	if name, ok := schemaDefs[fieldType]; ok {
+		return &schema{Ref: "#/$defs/" + name}
+	}
+
+	switch fieldType.Kind() {
+	case reflect.String:
+		return &schema{Type: "string"}
+	case reflect.Bool:
+		return &schema{Type: "boolean"}
+	case reflect.Int:
+		minimum := 0
+
+		return &schema{Type: "integer", Minimum: &minimum}
+	case reflect.Slice:
+		return &schema{Type: "array", Items: typeSchema(fieldType.Elem())}
+	case reflect.Map:
+		return &schema{Type: "object", AdditionalProperties: typeSchema(fieldType.Elem())}
+	default:
+		return structSchema(fieldType)
+	}
+}
+
+func enumValues[T any](values []T) []interface{} {
+
+	result := make([]interface{}, 0, len(values))
+
+	for _, value := range values {
+		result = append(result, value)
+	}
+
+	return result
+}
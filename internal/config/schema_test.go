@@ -0,0 +1,49 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+func TestSchema(t *testing.T) {
+
+	data, err := Schema()
+
+	assert.Errors(t, err, nil)
+
+	var got schema
+
+	err = json.Unmarshal(data, &got)
+
+	assert.Errors(t, err, nil)
+
+	t.Run("Describe client settings with enums", func(t *testing.T) {
+		client := got.Defs["client"]
+
+		assert.Strings(t, client.Properties["stachursky_mode"].Type, "integer")
+		assert.Strings(t, client.Properties["stachursky_mode"].Description, "Rounding precision (minutes)")
+		assert.Ints(t, *client.Properties["stachursky_mode"].Minimum, 1)
+		assert.Ints(t, *client.Properties["merge_gap"].Minimum, 0)
+		assert.Ints(t, len(client.Properties["rounding_strategy"].Enum), 4)
+		assert.Strings(t, client.Properties["aliases"].Items.Type, "string")
+		assert.Strings(t, client.Properties["enabled"].Type, "boolean")
+	})
+
+	t.Run("Reference workspaces and clients definitions", func(t *testing.T) {
+		workspaces := got.Properties["workspaces"]
+
+		assert.Strings(t, workspaces.AdditionalProperties.(map[string]interface{})["$ref"].(string), "#/$defs/workspace")
+		assert.Strings(t, got.Properties["default_client"].Ref, "#/$defs/client")
+		assert.Strings(t, got.Defs["workspace"].Properties["jira_migration_skip_tag"].Type, "string")
+		assert.Strings(t, got.Properties["include"].Type, "array")
+	})
+
+	t.Run("Skip internal fields", func(t *testing.T) {
+		_, sourceVersion := got.Properties["SourceVersion"]
+		_, files := got.Properties["Files"]
+
+		assert.Bools(t, sourceVersion || files, false)
+	})
+}
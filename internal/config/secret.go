@@ -0,0 +1,116 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+const (
+	ErrSecretCommandFailed      = ConfigErr("Secret command failed")
+	ErrSecretCommandEmptyOutput = ConfigErr("Secret command returned empty output")
+	ErrSecretConflict           = ConfigErr("Secret and secret command cannot be set at the same time")
+)
+
+type secretCommandRunner func(command string) (string, error)
+
+// secretResolver runs every secret command at most once and keeps its output in memory
+type secretResolver struct {
+	run   secretCommandRunner
+	cache map[string]string
+}
+
+func newSecretResolver(run secretCommandRunner) *secretResolver {
+	return &secretResolver{
+		run:   run,
+		cache: map[string]string{},
+	}
+}
+
+// ResolveSecrets replaces *_command settings of global and enabled clients with the command output
+func (c *Config) ResolveSecrets() error {
+	return c.resolveSecrets(newSecretResolver(runSecretCommand))
+}
+
+func (c *Config) resolveSecrets(resolver *secretResolver) error {
+
+	clockifyToken, err := resolver.resolve(c.Global.ClockifyToken, c.Global.ClockifyTokenCommand, "global.clockify_token_command")
+
+	if err != nil {
+		return err
+	}
+
+	c.Global.ClockifyToken = clockifyToken
+
+	for _, workspaceKey := range sortedKeys(c.Workspaces) {
+		clients := c.Workspaces[workspaceKey].Clients
+
+		for _, clientKey := range sortedKeys(clients) {
+			client := clients[clientKey]
+
+			if !client.Enabled {
+				continue
+			}
+
+			path := fmt.Sprintf("workspaces.%s.clients.%s.jira_password_command", workspaceKey, clientKey)
+			jiraPassword, err := resolver.resolve(client.JiraPassword, client.JiraPasswordCommand, path)
+
+			if err != nil {
+				return err
+			}
+
+			client.JiraPassword = jiraPassword
+		}
+	}
+
+	return nil
+}
+
+func (r *secretResolver) resolve(secret, command, path string) (string, error) {
+
+	if command == "" {
+		return secret, nil
+	}
+
+	if secret != "" {
+		return "", fmt.Errorf("%w - %s", ErrSecretConflict, path)
+	}
+
+	if output, ok := r.cache[command]; ok {
+		return output, nil
+	}
+
+	output, err := r.run(command)
+
+	// the output is never part of the error as it may contain the secret
+	if err != nil {
+		return "", fmt.Errorf("%w - %s (%s): %v", ErrSecretCommandFailed, path, command, err)
+	}
+
+	output = strings.TrimSpace(output)
+
+	if output == "" {
+		return "", fmt.Errorf("%w - %s (%s)", ErrSecretCommandEmptyOutput, path, command)
+	}
+
+	r.cache[command] = output
+
+	return output, nil
+}
+
+func runSecretCommand(command string) (string, error) {
+
+	var stdout bytes.Buffer
+
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdout = &stdout
+
+	err := cmd.Run()
+
+	if err != nil {
+		return "", err
+	}
+
+	return stdout.String(), nil
+}
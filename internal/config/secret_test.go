@@ -0,0 +1,103 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+func TestResolveSecrets(t *testing.T) {
+
+	newConfig := func() Config {
+		return Config{
+			Global: Global{ClockifyTokenCommand: "pass show clockify"},
+			Workspaces: Workspaces{
+				"ws_1": &Workspace{
+					Clients: Clients{
+						"client_1": &Client{Enabled: true, JiraPasswordCommand: "pass show jira"},
+						"client_2": &Client{Enabled: true, JiraPasswordCommand: "pass show jira"},
+						"client_3": &Client{Enabled: false, JiraPasswordCommand: "pass show disabled"},
+						"client_4": &Client{Enabled: true, JiraPassword: "plain-password"},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("Resolve secrets with cached command output", func(t *testing.T) {
+		calls := []string{}
+		resolver := newSecretResolver(func(command string) (string, error) {
+			calls = append(calls, command)
+
+			return "  " + command + "-secret\n", nil
+		})
+
+		config := newConfig()
+		err := config.resolveSecrets(resolver)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, config.Global.ClockifyToken, "pass show clockify-secret")
+		assert.Strings(t, config.Workspaces["ws_1"].Clients["client_1"].JiraPassword, "pass show jira-secret")
+		assert.Strings(t, config.Workspaces["ws_1"].Clients["client_2"].JiraPassword, "pass show jira-secret")
+		assert.Strings(t, config.Workspaces["ws_1"].Clients["client_3"].JiraPassword, "")
+		assert.Strings(t, config.Workspaces["ws_1"].Clients["client_4"].JiraPassword, "plain-password")
+		assert.StringSlices(t, calls, []string{"pass show clockify", "pass show jira"})
+	})
+
+	t.Run("Throw error without secret on failing command", func(t *testing.T) {
+		resolver := newSecretResolver(func(command string) (string, error) {
+			return "top-secret", errors.New("exit status 1")
+		})
+
+		config := newConfig()
+		err := config.resolveSecrets(resolver)
+
+		assert.ErrorsIs(t, err, ErrSecretCommandFailed)
+		assert.Strings(t, err.Error(), "Secret command failed - global.clockify_token_command (pass show clockify): exit status 1")
+	})
+
+	t.Run("Throw error on empty command output", func(t *testing.T) {
+		resolver := newSecretResolver(func(command string) (string, error) {
+			return "\n", nil
+		})
+
+		config := newConfig()
+		err := config.resolveSecrets(resolver)
+
+		assert.ErrorsIs(t, err, ErrSecretCommandEmptyOutput)
+	})
+
+	t.Run("Throw error when secret and command are set", func(t *testing.T) {
+		config := newConfig()
+		config.Global.ClockifyToken = "clockify-token"
+
+		err := config.resolveSecrets(newSecretResolver(runSecretCommand))
+
+		assert.ErrorsIs(t, err, ErrSecretConflict)
+	})
+
+	t.Run("Run shell command", func(t *testing.T) {
+		output, err := runSecretCommand("echo secret")
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, output, "secret\n")
+	})
+}
+
+func TestCombineClientPasswordCommand(t *testing.T) {
+
+	t.Run("Client password overrides default command", func(t *testing.T) {
+		client := (&Client{JiraPassword: "client-password"}).combineWithDefaultConfig(Client{JiraPasswordCommand: "pass show jira"}, nil)
+
+		assert.Strings(t, client.JiraPassword, "client-password")
+		assert.Strings(t, client.JiraPasswordCommand, "")
+	})
+
+	t.Run("Client command overrides default password", func(t *testing.T) {
+		client := (&Client{JiraPasswordCommand: "pass show jira"}).combineWithDefaultConfig(Client{JiraPassword: "default-password"}, nil)
+
+		assert.Strings(t, client.JiraPassword, "")
+		assert.Strings(t, client.JiraPasswordCommand, "pass show jira")
+	})
+}
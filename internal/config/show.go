@@ -0,0 +1,183 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	maskedSecret      = "********"
+	showCommentColumn = 60
+)
+
+var (
+	secretKeys = []string{"clockify_token", "jira_password"}
+)
+
+// ShowYaml returns configuration file in the current layout with secrets masked.
+// Environment variable references are kept as they are.
+func ShowYaml(yamlData []byte) (string, error) {
+
+	document, err := decodeDocument(yamlData)
+
+	if err != nil {
+		return "", err
+	}
+
+	return showDocument(document)
+}
+
+// ShowFile returns configuration file of any supported format as yaml with secrets masked
+func ShowFile(filePath string) (string, error) {
+
+	fileData, err := createFileConfigSource(filePath)
+
+	if err != nil {
+		return "", err
+	}
+
+	document, err := decodeFile(filePath, fileData)
+
+	if err != nil {
+		return "", err
+	}
+
+	return showDocument(document)
+}
+
+func showDocument(document yaml.MapSlice) (string, error) {
+
+	document, _, err := migrateDocument(document)
+
+	if err != nil {
+		return "", err
+	}
+
+	output, err := yaml.Marshal(maskDocument(document))
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrLoaderInvalidConfiguration, err)
+	}
+
+	return string(output), nil
+}
+
+// ShowResolved returns effective configuration of every workspace client with the source of each value
+func (c *Config) ShowResolved() string {
+
+	output := strings.Builder{}
+
+	writeLine(&output, 0, "version", c.Version, "")
+	writeLine(&output, 0, "global", nil, "")
+	writeStruct(&output, 1, c.Global, c.Global.sources)
+	writeLine(&output, 0, "workspaces", nil, "")
+
+	for _, workspaceKey := range sortedKeys(c.Workspaces) {
+		workspace := c.Workspaces[workspaceKey]
+
+		writeLine(&output, 1, workspaceKey, nil, "")
+		writeStruct(&output, 2, *workspace, workspace.sources)
+		writeLine(&output, 2, "clients", nil, "")
+
+		for _, clientKey := range sortedKeys(workspace.Clients) {
+			client := workspace.Clients[clientKey]
+
+			writeLine(&output, 3, clientKey, nil, "")
+			writeStruct(&output, 4, *client, client.sources)
+		}
+	}
+
+	return output.String()
+}
+
+// writeStruct writes every setting with value or known source
+func writeStruct(output *strings.Builder, indent int, settings interface{}, sources sources) {
+
+	value := reflect.ValueOf(settings)
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+
+		if !field.IsExported() || key == "" || field.Type.Kind() == reflect.Map {
+			continue
+		}
+
+		source := sources[key]
+
+		if value.Field(i).IsZero() && source == "" {
+			continue
+		}
+
+		writeLine(output, indent, key, maskSecret(key, value.Field(i).Interface()), source)
+	}
+}
+
+func writeLine(output *strings.Builder, indent int, key string, value interface{}, source string) {
+
+	line := strings.Repeat("  ", indent) + key + ":"
+
+	if value != nil {
+		line += " " + formatValue(value)
+	}
+
+	if source != "" {
+		line = fmt.Sprintf("%-*s # %s", showCommentColumn, line, source)
+	}
+
+	output.WriteString(line + "\n")
+}
+
+func formatValue(value interface{}) string {
+
+	if items, ok := value.([]string); ok {
+		formatted := make([]string, 0, len(items))
+
+		for _, item := range items {
+			formatted = append(formatted, formatValue(item))
+		}
+
+		return "[" + strings.Join(formatted, ", ") + "]"
+	}
+
+	output, _ := yaml.Marshal(value)
+
+	return strings.TrimSpace(string(output))
+}
+
+func maskSecret(key string, value interface{}) interface{} {
+
+	secret, ok := value.(string)
+
+	if !ok || secret == "" || strings.Contains(secret, "${") {
+		return value
+	}
+
+	for _, secretKey := range secretKeys {
+		if key == secretKey {
+			return maskedSecret
+		}
+	}
+
+	return value
+}
+
+func maskDocument(document yaml.MapSlice) yaml.MapSlice {
+
+	result := make(yaml.MapSlice, 0, len(document))
+
+	for _, item := range document {
+		if nested, ok := item.Value.(yaml.MapSlice); ok {
+			item.Value = maskDocument(nested)
+		} else {
+			item.Value = maskSecret(fmt.Sprint(item.Key), item.Value)
+		}
+
+		result = append(result, item)
+	}
+
+	return result
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+const (
+	layeredConfig = `version: 1
+global:
+  clockify_token: clockify-token
+  period: 1
+default_client:
+  jira_host: https://jira.atlassian.net
+  jira_password: ${JIRA_PASSWORD:-jira-password}
+  stachursky_mode: 15
+  merge_gap: 5
+  enabled: true
+default_workspace:
+  jira_migration_success_tag: logged
+workspaces:
+  ws_1:
+    workspace_id: ws-1
+    clients:
+      client_1:
+        aliases:
+          - Client One
+        jira_password: client-password
+        merge_gap: 0
+        enabled: false
+      client_2: {}
+`
+)
+
+func TestTriStateMerge(t *testing.T) {
+
+	config, err := Load([]byte(layeredConfig))
+
+	assert.Errors(t, err, nil)
+
+	client1 := config.Workspaces["ws_1"].Clients["client_1"]
+	assert.Bools(t, client1.Enabled, false)
+	assert.Ints(t, client1.MergeGap, 0)
+	assert.Strings(t, client1.JiraHost, "https://jira.atlassian.net")
+
+	client2 := config.Workspaces["ws_1"].Clients["client_2"]
+	assert.Bools(t, client2.Enabled, true)
+	assert.Ints(t, client2.MergeGap, 5)
+}
+
+func TestShowResolved(t *testing.T) {
+
+	config, err := Load([]byte(layeredConfig))
+
+	assert.Errors(t, err, nil)
+
+	config.OverwritePeriodSetting(3)
+	config.Workspaces["ws_1"].Clients["client_2"].overwritePrecisionSetting(30)
+
+	want := `version: 1
+global:
+  clockify_token: '********'                                 # global
+  period: 3                                                  # flag
+workspaces:
+  ws_1:
+    workspace_id: ws-1                                       # workspace
+    jira_migration_success_tag: logged                       # default
+    clients:
+      client_1:
+        aliases: [Client One]                                # client
+        jira_host: https://jira.atlassian.net                # default
+        jira_password: '********'                            # client
+        stachursky_mode: 15                                  # default
+        merge_gap: 0                                         # client
+        enabled: false                                       # client
+      client_2:
+        jira_host: https://jira.atlassian.net                # default
+        jira_password: '********'                            # default
+        stachursky_mode: 30                                  # flag
+        merge_gap: 5                                         # default
+        enabled: true                                        # default
+`
+
+	assert.Strings(t, config.ShowResolved(), want)
+}
+
+func TestShowYaml(t *testing.T) {
+
+	got, err := ShowYaml([]byte(layeredConfig))
+
+	assert.Errors(t, err, nil)
+	assert.Bools(t, strings.Contains(got, "\n  clockify_token: '********'\n"), true)
+	assert.Bools(t, strings.Contains(got, "\n  jira_password: ${JIRA_PASSWORD:-jira-password}\n"), true)
+	assert.Bools(t, strings.Contains(got, "\n        jira_password: '********'\n"), true)
+}
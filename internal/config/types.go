@@ -0,0 +1,177 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// checkLayerTypes decodes every value of every configuration file into the type of its setting,
+// so type errors point to the file, line and path the user wrote instead of the merged document
+func checkLayerTypes(layers []layer) []Problem {
+
+	result := []Problem{}
+
+	for _, layer := range layers {
+		document, ok := layerNode(layer)
+
+		if !ok {
+			continue
+		}
+
+		for _, problem := range checkTypes(document, reflect.TypeOf(Config{}), "") {
+			problem.File = layer.path
+			result = append(result, problem)
+		}
+	}
+
+	return result
+}
+
+// layerNode returns yaml node of the file, toml files and files migrated from older layouts
+// are checked in their migrated form - their problems have no line
+func layerNode(layer layer) (*yamlv3.Node, bool) {
+
+	if layer.sourceVersion == CurrentVersion && FileFormat(layer.path) != FormatToml {
+		if document, ok := decodeNode(layer.fileData); ok {
+			return document, true
+		}
+	}
+
+	yamlData, err := yaml.Marshal(layer.document)
+
+	if err != nil {
+		return nil, false
+	}
+
+	document, ok := decodeNode(yamlData)
+
+	if ok {
+		clearLines(document)
+	}
+
+	return document, ok
+}
+
+func checkTypes(node *yamlv3.Node, target reflect.Type, path string) []Problem {
+
+	for target.Kind() == reflect.Pointer {
+		target = target.Elem()
+	}
+
+	if node.Kind == yamlv3.AliasNode {
+		node = node.Alias
+	}
+
+	// empty value keeps the default
+	if node.Kind == yamlv3.ScalarNode && node.Tag == "!!null" {
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.Struct:
+		if node.Kind != yamlv3.MappingNode {
+			return []Problem{typeProblem(node, path, "has to be a mapping")}
+		}
+
+		result := []Problem{}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			field, ok := fieldByYamlKey(target, node.Content[i].Value)
+
+			// unknown keys are ignored like by the loader
+			if !ok {
+				continue
+			}
+
+			result = append(result, checkTypes(node.Content[i+1], field.Type, joinPath(path, node.Content[i].Value))...)
+		}
+
+		return result
+	case reflect.Map:
+		if node.Kind != yamlv3.MappingNode {
+			return []Problem{typeProblem(node, path, "has to be a mapping")}
+		}
+
+		result := []Problem{}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			result = append(result, checkTypes(node.Content[i+1], target.Elem(), joinPath(path, node.Content[i].Value))...)
+		}
+
+		return result
+	case reflect.Slice:
+		if node.Kind != yamlv3.SequenceNode {
+			return []Problem{typeProblem(node, path, "has to be a list")}
+		}
+
+		result := []Problem{}
+
+		for index, item := range node.Content {
+			result = append(result, checkTypes(item, target.Elem(), fmt.Sprintf("%s[%d]", path, index))...)
+		}
+
+		return result
+	}
+
+	if node.Kind != yamlv3.ScalarNode {
+		return []Problem{typeProblem(node, path, "has to be %s", typeName(target))}
+	}
+
+	// the loader decodes with yaml.v2 - check the value the same way
+	scalarData, err := yamlv3.Marshal(node)
+
+	if err == nil {
+		err = yaml.Unmarshal(scalarData, reflect.New(target).Interface())
+	}
+
+	if err != nil {
+		return []Problem{typeProblem(node, path, "%q has to be %s", node.Value, typeName(target))}
+	}
+
+	return nil
+}
+
+func typeProblem(node *yamlv3.Node, path, message string, args ...interface{}) Problem {
+	return Problem{Path: path, Line: node.Line, Message: fmt.Sprintf(message, args...)}
+}
+
+func fieldByYamlKey(target reflect.Type, key string) (reflect.StructField, bool) {
+
+	for index := 0; index < target.NumField(); index++ {
+		field := target.Field(index)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+
+		if field.IsExported() && name == key {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+func typeName(target reflect.Type) string {
+
+	switch target.Kind() {
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "a whole number"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	default:
+		return "a text value"
+	}
+}
+
+func clearLines(node *yamlv3.Node) {
+
+	node.Line = 0
+
+	for _, item := range node.Content {
+		clearLines(item)
+	}
+}
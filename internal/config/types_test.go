@@ -0,0 +1,69 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+func TestCheckLayerTypes(t *testing.T) {
+
+	dir := t.TempDir()
+
+	sharedPath := writeLayerFile(t, filepath.Join(dir, "shared.yaml"), `default_client:
+  stachursky_mode: fifteen
+`)
+	userPath := writeLayerFile(t, filepath.Join(dir, "config.yaml"), `include:
+  - shared.yaml
+global:
+  clockify_token: token
+  period: 3
+workspaces:
+  ws_1:
+    workspace_id: workspace_1
+    clients:
+      client_1:
+        enabled: maybe
+        aliases: Client One
+        merge_gap: 5
+`)
+	tomlPath := writeLayerFile(t, filepath.Join(dir, "config.toml"), `[global]
+period = "three"
+`)
+
+	t.Run("Report file, line and path of every type error", func(t *testing.T) {
+		layers, err := loadLayers([]string{userPath}, userPath, "")
+
+		assert.Errors(t, err, nil)
+
+		problems := checkLayerTypes(layers)
+
+		assert.Ints(t, len(problems), 3)
+		assert.Strings(t, problems[0].String(), sharedPath+`:2: default_client.stachursky_mode: "fifteen" has to be a whole number`)
+		assert.Strings(t, problems[1].String(), userPath+`:11: workspaces.ws_1.clients.client_1.enabled: "maybe" has to be true or false`)
+		assert.Strings(t, problems[2].String(), userPath+`:12: workspaces.ws_1.clients.client_1.aliases: has to be a list`)
+	})
+
+	t.Run("Report toml type errors without line", func(t *testing.T) {
+		layers, _ := loadLayers([]string{tomlPath}, tomlPath, "")
+
+		problems := checkLayerTypes(layers)
+
+		assert.Ints(t, len(problems), 1)
+		assert.Strings(t, problems[0].String(), tomlPath+`: global.period: "three" has to be a whole number`)
+	})
+
+	t.Run("Return type errors from validate", func(t *testing.T) {
+		problems, err := ValidateFile(userPath)
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, len(problems), 3)
+	})
+
+	t.Run("Accept valid values", func(t *testing.T) {
+		layers, _ := loadLayers([]string{"./examples/config.yaml"}, "./examples/config.yaml", "")
+
+		assert.Ints(t, len(checkLayerTypes(layers)), 0)
+	})
+}
@@ -0,0 +1,367 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/period"
+	"github.com/kruc/clockify-to-jira/internal/rounding"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+const (
+	ErrValidationFailed = ConfigErr("Invalid configuration")
+)
+
+var (
+	jiraApiVersions    = []int{0, 2, 3}
+	aggregations       = []string{"", "none", "issue_day"}
+	minDurationActions = []string{"", "skip", "merge", "log"}
+	capActions         = []string{"", "warn", "block", "scale"}
+	weekdays           = []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"}
+	jiraProjectKey     = regexp.MustCompile(`^[A-Z][A-Z0-9]+$`)
+)
+
+// Problem is a single configuration issue found by Validate
+type Problem struct {
+	File    string
+	Path    string
+	Line    int
+	Message string
+}
+
+func (p Problem) String() string {
+
+	if p.File != "" && p.Line == 0 {
+		return fmt.Sprintf("%s: %s: %s", p.File, p.Path, p.Message)
+	}
+
+	if p.File != "" {
+		return fmt.Sprintf("%s:%d: %s: %s", p.File, p.Line, p.Path, p.Message)
+	}
+
+	if p.Line == 0 {
+		return fmt.Sprintf("%s: %s", p.Path, p.Message)
+	}
+
+	return fmt.Sprintf("line %d: %s: %s", p.Line, p.Path, p.Message)
+}
+
+type problems []Problem
+
+func formatProblems(problems []Problem) string {
+
+	lines := make([]string, 0, len(problems))
+
+	for _, problem := range problems {
+		lines = append(lines, problem.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (p *problems) add(path, message string, args ...interface{}) {
+	*p = append(*p, Problem{Path: path, Message: fmt.Sprintf(message, args...)})
+}
+
+// ValidateFile loads layered configuration and returns every problem with its file and line number
+func ValidateFile(filePath string) ([]Problem, error) {
+
+	config, layers, typeProblems, err := loadLayeredConfig(filePath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(typeProblems) != 0 {
+		return typeProblems, nil
+	}
+
+	return locateLayerProblems(config.Validate(), layers), nil
+}
+
+// Validate checks semantics of configuration combined with default workspace and client
+func (c *Config) Validate() []Problem {
+
+	result := problems{}
+
+	if c.Global.ClockifyToken == "" && c.Global.ClockifyTokenCommand == "" {
+		result.add("global.clockify_token", "clockify token is required")
+	}
+
+	if c.Global.Period < 0 {
+		result.add("global.period", "has to be greater than or equal to 0")
+	}
+
+	if c.Global.RetryLimit < 0 {
+		result.add("global.retry_limit", "has to be greater than or equal to 0")
+	}
+
+	validateCaps(&result, "global", c.Global.DailyCap, c.Global.WeeklyCap, c.Global.CapAction)
+
+	if _, err := time.LoadLocation(c.Global.Timezone); err != nil {
+		result.add("global.timezone", "unknown timezone %q - use IANA name, e.g. Europe/Warsaw", c.Global.Timezone)
+	}
+
+	if _, err := period.ParseWeekday(c.Global.WeekStart); err != nil && c.Global.WeekStart != "" {
+		result.add("global.week_start", "has to be one of %s", strings.Join(weekdays, ", "))
+	}
+
+	if len(c.Workspaces) == 0 {
+		result.add("workspaces", "at least one workspace is required")
+	}
+
+	for _, workspaceKey := range sortedKeys(c.Workspaces) {
+		c.Workspaces[workspaceKey].validate(&result, "workspaces."+workspaceKey)
+		c.validateProfileReferences(&result, workspaceKey)
+	}
+
+	for _, profileKey := range sortedKeys(c.Clients) {
+		profile := c.Clients[profileKey]
+
+		if profile.ClockifyClientId != "" {
+			result.add("clients."+profileKey+".clockify_client_id", "clockify client id is workspace specific - set it in workspace client")
+		}
+
+		if profile.Profile != "" {
+			result.add("clients."+profileKey+".profile", "profile cannot reference another profile")
+		}
+	}
+
+	return result
+}
+
+func (c *Config) validateProfileReferences(result *problems, workspaceKey string) {
+
+	clients := c.Workspaces[workspaceKey].Clients
+
+	for _, clientKey := range sortedKeys(clients) {
+		profile := clients[clientKey].Profile
+
+		if _, ok := c.Clients[profile]; profile != "" && !ok {
+			result.add("workspaces."+workspaceKey+".clients."+clientKey+".profile", "unknown profile %q - add it to top level clients", profile)
+		}
+	}
+}
+
+func (w *Workspace) validate(result *problems, path string) {
+
+	if w.WorkspaceId == "" {
+		result.add(path+".workspace_id", "workspace id is required")
+	}
+
+	tags := map[string]string{
+		"jira_migration_failed_tag":  w.JiraMigrationFailedTag,
+		"jira_migration_skip_tag":    w.JiraMigrationSkipTag,
+		"jira_migration_success_tag": w.JiraMigrationSuccessTag,
+	}
+
+	usedTags := map[string]string{}
+
+	for _, key := range sortedKeys(tags) {
+		tag := tags[key]
+
+		if tag == "" {
+			result.add(path+"."+key, "tag is required")
+			continue
+		}
+
+		if usedKey, ok := usedTags[tag]; ok {
+			result.add(path+"."+key, "tag %q is already used as %s", tag, usedKey)
+			continue
+		}
+
+		usedTags[tag] = key
+	}
+
+	for _, clientKey := range sortedKeys(w.Clients) {
+		w.Clients[clientKey].validate(result, path+".clients."+clientKey)
+	}
+}
+
+func (c *Client) validate(result *problems, path string) {
+
+	if c.Enabled {
+		if c.JiraHost == "" {
+			result.add(path+".jira_host", "jira host is required")
+		}
+
+		if c.JiraUsername == "" {
+			result.add(path+".jira_username", "jira username is required")
+		}
+
+		if c.JiraPassword == "" && c.JiraPasswordCommand == "" {
+			result.add(path+".jira_password", "jira password or jira_password_command is required")
+		}
+	}
+
+	if c.JiraHost != "" && !isValidUrl(c.JiraHost) {
+		result.add(path+".jira_host", "%q is not a valid url", c.JiraHost)
+	}
+
+	if !slices.Contains(jiraApiVersions, c.JiraApiVersion) {
+		result.add(path+".jira_api_version", "has to be 2 or 3")
+	}
+
+	for _, projectKey := range c.JiraProjects {
+		if !jiraProjectKey.MatchString(projectKey) {
+			result.add(path+".jira_projects", "%q is not a jira project key, e.g. ABC", projectKey)
+		}
+	}
+
+	// missing stachursky_mode is set from --tryb-niepokorny flag default
+	if c.StachurskyMode < 0 {
+		result.add(path+".stachursky_mode", "cannot be negative")
+	} else if c.StachurskyMode == 0 && c.sources.has("stachursky_mode") {
+		result.add(path+".stachursky_mode", "has to be greater than 0 - remove it to use --tryb-niepokorny flag value")
+	} else if c.StachurskyMode > 0 && (c.RoundingGrace < 0 || c.RoundingGrace >= c.StachurskyMode) {
+		result.add(path+".rounding_grace", "has to be between 0 and stachursky_mode")
+	}
+
+	if c.RoundingStrategy != "" && !slices.Contains(rounding.Strategies, c.RoundingStrategy) {
+		result.add(path+".rounding_strategy", "has to be one of %s", strings.Join(rounding.Strategies, ", "))
+	}
+
+	if c.RoundingMinimum < 0 {
+		result.add(path+".rounding_minimum", "cannot be negative")
+	}
+
+	if !slices.Contains(aggregations, c.Aggregation) {
+		result.add(path+".aggregation", "has to be one of %s", strings.Join(aggregations[1:], ", "))
+	}
+
+	if c.MergeGap < 0 {
+		result.add(path+".merge_gap", "cannot be negative")
+	}
+
+	if c.MinDuration < 0 {
+		result.add(path+".min_duration", "cannot be negative")
+	}
+
+	if !slices.Contains(minDurationActions, c.MinDurationAction) {
+		result.add(path+".min_duration_action", "has to be one of %s", strings.Join(minDurationActions[1:], ", "))
+	}
+
+	validateCaps(result, path, c.DailyCap, c.WeeklyCap, c.CapAction)
+}
+
+func validateCaps(result *problems, path string, dailyCap, weeklyCap int, capAction string) {
+
+	if dailyCap < 0 {
+		result.add(path+".daily_cap", "cannot be negative")
+	}
+
+	if weeklyCap < 0 {
+		result.add(path+".weekly_cap", "cannot be negative")
+	}
+
+	if !slices.Contains(capActions, capAction) {
+		result.add(path+".cap_action", "has to be one of %s", strings.Join(capActions[1:], ", "))
+	}
+}
+
+func isValidUrl(rawUrl string) bool {
+
+	parsedUrl, err := url.Parse(rawUrl)
+
+	return err == nil && (parsedUrl.Scheme == "http" || parsedUrl.Scheme == "https") && parsedUrl.Host != ""
+}
+
+// locateProblems sets line of the closest existing yaml node for every problem path
+func locateProblems(problems []Problem, yamlData []byte) []Problem {
+
+	document, ok := decodeNode(yamlData)
+
+	if !ok {
+		return problems
+	}
+
+	for index := range problems {
+		problems[index].Line, _ = findLine(document, strings.Split(problems[index].Path, "."))
+	}
+
+	return problems
+}
+
+// locateLayerProblems sets file and line of the last layer defining problem path,
+// closest existing node of the last layer is used when none of them defines it
+func locateLayerProblems(problems []Problem, layers []layer) []Problem {
+
+	for index := range problems {
+		path := strings.Split(problems[index].Path, ".")
+
+		for layerIndex := len(layers) - 1; layerIndex >= 0; layerIndex-- {
+			// toml files have no yaml nodes - problems defined only there point to the first layer
+			line, found := 0, false
+
+			if document, ok := decodeNode(layers[layerIndex].fileData); ok {
+				line, found = findLine(document, path)
+			}
+
+			if found || layerIndex == 0 {
+				problems[index].File = layers[layerIndex].path
+				problems[index].Line = line
+				break
+			}
+		}
+	}
+
+	return problems
+}
+
+func decodeNode(yamlData []byte) (*yamlv3.Node, bool) {
+
+	var document yamlv3.Node
+
+	if yamlv3.Unmarshal(yamlData, &document) != nil || len(document.Content) == 0 {
+		return nil, false
+	}
+
+	return document.Content[0], true
+}
+
+func findLine(node *yamlv3.Node, path []string) (int, bool) {
+
+	line := node.Line
+
+	for _, key := range path {
+
+		if node.Kind != yamlv3.MappingNode {
+			return line, false
+		}
+
+		found := false
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				line = node.Content[i].Line
+				node = node.Content[i+1]
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return line, false
+		}
+	}
+
+	return line, true
+}
+
+func sortedKeys[T any](items map[string]T) []string {
+
+	keys := make([]string, 0, len(items))
+
+	for key := range items {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
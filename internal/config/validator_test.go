@@ -0,0 +1,201 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+func TestValidate(t *testing.T) {
+
+	t.Run("Return no problems for valid configuration", func(t *testing.T) {
+		problems, err := ValidateFile("./examples/config.yaml")
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, len(problems), 0)
+	})
+
+	t.Run("Return every problem with yaml path and line", func(t *testing.T) {
+
+		yamlData := []byte(`global:
+  clockify_token: clockify-token
+  cap_action: stop
+
+default_workspace:
+  jira_migration_failed_tag: failed
+  jira_migration_skip_tag: logged
+  jira_migration_success_tag: logged
+
+workspaces:
+  ws_1:
+    clients:
+      client_1:
+        enabled: true
+        jira_host: domain.atlassian.net
+        jira_username: username@domain.com
+        jira_password: password
+        stachursky_mode: -15
+        rounding_strategy: random
+`)
+
+		config, err := Load(yamlData)
+
+		assert.Errors(t, err, nil)
+
+		got := []string{}
+
+		for _, problem := range locateProblems(config.Validate(), yamlData) {
+			got = append(got, problem.String())
+		}
+
+		want := []string{
+			`line 3: global.cap_action: has to be one of warn, block, scale`,
+			`line 11: workspaces.ws_1.workspace_id: workspace id is required`,
+			`line 11: workspaces.ws_1.jira_migration_success_tag: tag "logged" is already used as jira_migration_skip_tag`,
+			`line 15: workspaces.ws_1.clients.client_1.jira_host: "domain.atlassian.net" is not a valid url`,
+			`line 18: workspaces.ws_1.clients.client_1.stachursky_mode: cannot be negative`,
+			`line 19: workspaces.ws_1.clients.client_1.rounding_strategy: has to be one of nearest, ceil, floor, grace`,
+		}
+
+		assert.StringSlices(t, got, want)
+	})
+
+	t.Run("Reject explicit zero stachursky mode", func(t *testing.T) {
+
+		config, err := Load([]byte(`global:
+  clockify_token: clockify-token
+default_workspace:
+  workspace_id: ws-1
+  jira_migration_failed_tag: failed
+  jira_migration_skip_tag: skip
+  jira_migration_success_tag: logged
+workspaces:
+  ws_1:
+    clients:
+      client_1:
+        stachursky_mode: 0
+      client_2:
+        merge_gap: 5
+`))
+
+		assert.Errors(t, err, nil)
+
+		got := []string{}
+
+		for _, problem := range config.Validate() {
+			got = append(got, problem.String())
+		}
+
+		assert.StringSlices(t, got, []string{
+			"workspaces.ws_1.clients.client_1.stachursky_mode: has to be greater than 0 - remove it to use --tryb-niepokorny flag value",
+		})
+	})
+
+	t.Run("Require credentials of enabled clients only", func(t *testing.T) {
+		config := Config{
+			Global: Global{ClockifyTokenCommand: "pass show clockify"},
+			Workspaces: Workspaces{
+				"ws_1": &Workspace{
+					WorkspaceId:             "ws-1",
+					JiraMigrationFailedTag:  "failed",
+					JiraMigrationSkipTag:    "skip",
+					JiraMigrationSuccessTag: "logged",
+					Clients: Clients{
+						"client_1": &Client{Enabled: true, StachurskyMode: 15},
+						"client_2": &Client{Enabled: false, StachurskyMode: 15},
+					},
+				},
+			},
+		}
+
+		got := []string{}
+
+		for _, problem := range config.Validate() {
+			got = append(got, problem.String())
+		}
+
+		want := []string{
+			"workspaces.ws_1.clients.client_1.jira_host: jira host is required",
+			"workspaces.ws_1.clients.client_1.jira_username: jira username is required",
+			"workspaces.ws_1.clients.client_1.jira_password: jira password or jira_password_command is required",
+		}
+
+		assert.StringSlices(t, got, want)
+	})
+
+	t.Run("Throw validation error on load", func(t *testing.T) {
+		_, err := LoadFromFile("./examples/invalid-values-config.yaml")
+
+		assert.ErrorsIs(t, err, ErrValidationFailed)
+		assert.Strings(t, err.Error(), "Invalid configuration:\n./examples/invalid-values-config.yaml:3: workspaces: at least one workspace is required")
+	})
+
+	t.Run("Return problems of client profiles", func(t *testing.T) {
+
+		config, err := Load([]byte(`clients:
+  acme:
+    clockify_client_id: client-id
+    profile: other
+workspaces:
+  ws_1:
+    clients:
+      acme:
+        profile: acme
+      beta:
+        profile: beta
+`))
+
+		assert.Errors(t, err, nil)
+
+		problems := map[string]string{}
+
+		for _, problem := range config.Validate() {
+			problems[problem.Path] = problem.Message
+		}
+
+		assert.Strings(t, problems["workspaces.ws_1.clients.beta.profile"], `unknown profile "beta" - add it to top level clients`)
+		assert.Strings(t, problems["workspaces.ws_1.clients.acme.profile"], "")
+		assert.Strings(t, problems["clients.acme.clockify_client_id"], "clockify client id is workspace specific - set it in workspace client")
+		assert.Strings(t, problems["clients.acme.profile"], "profile cannot reference another profile")
+	})
+
+	t.Run("Return problems of calendar settings", func(t *testing.T) {
+
+		config := Config{Global: Global{ClockifyToken: "clockify-token", Timezone: "Mars/Olympus", WeekStart: "someday"}}
+
+		problems := map[string]string{}
+
+		for _, problem := range config.Validate() {
+			problems[problem.Path] = problem.Message
+		}
+
+		assert.Strings(t, problems["global.timezone"], `unknown timezone "Mars/Olympus" - use IANA name, e.g. Europe/Warsaw`)
+		assert.Strings(t, problems["global.week_start"], "has to be one of monday, tuesday, wednesday, thursday, friday, saturday, sunday")
+	})
+
+	t.Run("Return problem of negative retry limit", func(t *testing.T) {
+
+		config := Config{Global: Global{ClockifyToken: "clockify-token", RetryLimit: -1}}
+
+		problems := map[string]string{}
+
+		for _, problem := range config.Validate() {
+			problems[problem.Path] = problem.Message
+		}
+
+		assert.Strings(t, problems["global.retry_limit"], "has to be greater than or equal to 0")
+		assert.Ints(t, config.GetRetryLimit(), -1)
+		assert.Ints(t, (&Config{}).GetRetryLimit(), DefaultRetryLimit)
+	})
+
+	t.Run("Return problem of invalid jira project key", func(t *testing.T) {
+
+		problems := problems{}
+
+		(&Client{JiraApiVersion: 2, JiraProjects: []string{"ABC", "abc-1"}}).validate(&problems, "workspaces.ws_1.clients.acme")
+
+		assert.Ints(t, len(problems), 1)
+		assert.Strings(t, problems[0].Path, "workspaces.ws_1.clients.acme.jira_projects")
+		assert.Strings(t, problems[0].Message, `"abc-1" is not a jira project key, e.g. ABC`)
+	})
+}
@@ -1,46 +1,109 @@
 package config
 
+import (
+	"slices"
+)
+
 const (
-	ErrClientNotFound = ConfigErr("Cannot find client in given workspace")
+	ErrClientNotFound            = ConfigErr("Cannot find client in given workspace")
+	ErrClientForJiraHostNotFound = ConfigErr("Cannot find client with given jira host in workspace")
+
+	ClientMatchId       = "clockify_client_id"
+	ClientMatchName     = "name"
+	ClientMatchAlias    = "alias"
+	ClientMatchJiraHost = "jira_host"
 )
 
 type Clients map[string]*Client
 
 type Workspace struct {
-	WorkspaceId             string  `yaml:"workspace_id"`
-	JiraMigrationFailedTag  string  `yaml:"jira_migration_failed_tag"`
-	JiraMigrationSkipTag    string  `yaml:"jira_migration_skip_tag"`
-	JiraMigrationSuccessTag string  `yaml:"jira_migration_success_tag"`
+	WorkspaceId             string  `yaml:"workspace_id,omitempty"`
+	JiraMigrationFailedTag  string  `yaml:"jira_migration_failed_tag,omitempty"`
+	JiraMigrationSkipTag    string  `yaml:"jira_migration_skip_tag,omitempty"`
+	JiraMigrationSuccessTag string  `yaml:"jira_migration_success_tag,omitempty"`
 	Clients                 Clients `yaml:"clients"`
+
+	keys    presence
+	sources sources
 }
 
-func (w *Workspace) GetClient(clientId string) (*Client, error) {
+// GetClient resolves client by clockify client id, then by normalised config key and finally by normalised alias.
+// Returned rule tells which of them matched.
+func (w *Workspace) GetClient(clockifyClientId, clockifyClientName string) (string, *Client, string, error) {
+
+	clientIds := sortedKeys(w.Clients)
+
+	if clockifyClientId != "" {
+		for _, id := range clientIds {
+			if w.Clients[id].ClockifyClientId == clockifyClientId {
+				return id, w.Clients[id], ClientMatchId, nil
+			}
+		}
+	}
+
+	name := NormalizeClientName(clockifyClientName)
 
-	client, ok := w.Clients[clientId]
+	for _, id := range clientIds {
+		if NormalizeClientName(id) == name {
+			return id, w.Clients[id], ClientMatchName, nil
+		}
+	}
 
-	if !ok {
-		return &Client{}, ErrClientNotFound
+	for _, id := range clientIds {
+		for _, alias := range w.Clients[id].Aliases {
+			if NormalizeClientName(alias) == name {
+				return id, w.Clients[id], ClientMatchAlias, nil
+			}
+		}
 	}
 
-	return client, nil
+	return "", &Client{}, "", ErrClientNotFound
 }
 
-func (w *Workspace) combineWithDefaultConfig(defaultWorkspace Workspace, defaultClient Client) *Workspace {
+// FindClientByJiraHost prefers preferredClientId when several clients share the same jira host
+func (w *Workspace) FindClientByJiraHost(jiraHost, preferredClientId string) (string, *Client, error) {
+
+	preferredClient, ok := w.Clients[preferredClientId]
+
+	if ok && preferredClient.hasJiraHost(jiraHost) {
+		return preferredClientId, preferredClient, nil
+	}
+
+	clientIds := make([]string, 0, len(w.Clients))
+
+	for id := range w.Clients {
+		clientIds = append(clientIds, id)
+	}
+
+	slices.Sort(clientIds)
+
+	for _, id := range clientIds {
+		if w.Clients[id].hasJiraHost(jiraHost) {
+			return id, w.Clients[id], nil
+		}
+	}
+
+	return "", &Client{}, ErrClientForJiraHostNotFound
+}
+
+func (w *Workspace) combineWithDefaultConfig(defaultWorkspace Workspace, defaultClient Client, profiles Clients) *Workspace {
 	workspace := defaultWorkspace
+	workspace.keys = w.keys
+	workspace.sources = w.keys.sources(SourceWorkspace, defaultWorkspace.keys.sources(SourceDefault, nil))
 
-	if w.WorkspaceId != "" {
+	if w.keys.overrides("workspace_id", w.WorkspaceId != "") {
 		workspace.WorkspaceId = w.WorkspaceId
 	}
 
-	if w.JiraMigrationFailedTag != "" {
+	if w.keys.overrides("jira_migration_failed_tag", w.JiraMigrationFailedTag != "") {
 		workspace.JiraMigrationFailedTag = w.JiraMigrationFailedTag
 	}
 
-	if w.JiraMigrationSkipTag != "" {
+	if w.keys.overrides("jira_migration_skip_tag", w.JiraMigrationSkipTag != "") {
 		workspace.JiraMigrationSkipTag = w.JiraMigrationSkipTag
 	}
 
-	if w.JiraMigrationSuccessTag != "" {
+	if w.keys.overrides("jira_migration_success_tag", w.JiraMigrationSuccessTag != "") {
 		workspace.JiraMigrationSuccessTag = w.JiraMigrationSuccessTag
 	}
 
@@ -49,7 +112,7 @@ func (w *Workspace) combineWithDefaultConfig(defaultWorkspace Workspace, default
 	}
 
 	for id, client := range w.Clients {
-		workspace.Clients[id] = client.combineWithDefaultConfig(defaultClient)
+		workspace.Clients[id] = client.combineWithDefaultConfig(defaultClient, profiles[client.Profile])
 	}
 
 	return &workspace
@@ -61,3 +124,10 @@ func (w *Workspace) overwritePrecisionSetting(precision int) {
 		w.Clients[id].overwritePrecisionSetting(precision)
 	}
 }
+
+func (w *Workspace) setDefaultPrecision(precision int) {
+
+	for id := range w.Clients {
+		w.Clients[id].setDefaultPrecision(precision)
+	}
+}
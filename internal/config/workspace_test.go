@@ -40,7 +40,7 @@ func TestGetWorkspaceConfig(t *testing.T) {
 			},
 		}
 
-		finalWorkspace := workspace.combineWithDefaultConfig(defaultWorkspace, defaultClient)
+		finalWorkspace := workspace.combineWithDefaultConfig(defaultWorkspace, defaultClient, nil)
 
 		assert.Strings(t, finalWorkspace.WorkspaceId, workspaceId)
 		assert.Strings(t, finalWorkspace.JiraMigrationFailedTag, jiraMigrationFailedTagDefault)
@@ -71,7 +71,7 @@ func TestGetWorkspaceConfig(t *testing.T) {
 			},
 		}
 
-		finalWorkspace := workspace.combineWithDefaultConfig(defaultWorkspace, defaultClient)
+		finalWorkspace := workspace.combineWithDefaultConfig(defaultWorkspace, defaultClient, nil)
 
 		assert.Strings(t, finalWorkspace.WorkspaceId, workspaceId)
 		assert.Strings(t, finalWorkspace.JiraMigrationFailedTag, jiraMigrationFailedTagDefault)
@@ -99,7 +99,7 @@ func TestGetWorkspaceConfig(t *testing.T) {
 			},
 		}
 
-		finalWorkspace := workspace.combineWithDefaultConfig(defaultWorkspace, defaultClient)
+		finalWorkspace := workspace.combineWithDefaultConfig(defaultWorkspace, defaultClient, nil)
 
 		assert.Strings(t, finalWorkspace.WorkspaceId, workspaceId)
 		assert.Strings(t, finalWorkspace.JiraMigrationFailedTag, jiraMigrationFailedTag)
@@ -119,18 +119,38 @@ func TestGetWorkspaceConfig(t *testing.T) {
 
 func TestGetClient(t *testing.T) {
 
-	t.Run("Get client config", func(t *testing.T) {
+	workspace := Workspace{
+		Clients: Clients{
+			"acme corp":  &Client{JiraHost: "https://acme.atlassian.net"},
+			"lodz":       &Client{Aliases: []string{"Łódź Sp. z o.o."}},
+			"renamed":    &Client{ClockifyClientId: "clockifyClientId1"},
+			"other_name": &Client{Aliases: []string{"Acme Corp"}},
+		},
+	}
 
-		workspace := Workspace{
-			Clients: Clients{
-				clientId1: client,
-			},
-		}
+	t.Run("Get client config by clockify client id", func(t *testing.T) {
+		clientId, _, rule, err := workspace.GetClient("clockifyClientId1", "Old Name")
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, clientId, "renamed")
+		assert.Strings(t, rule, ClientMatchId)
+	})
+
+	t.Run("Get client config by normalised name", func(t *testing.T) {
+		clientId, client, rule, err := workspace.GetClient("unknownId", "ACME  Corp")
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, clientId, "acme corp")
+		assert.Strings(t, client.JiraHost, "https://acme.atlassian.net")
+		assert.Strings(t, rule, ClientMatchName)
+	})
 
-		client, err := workspace.GetClient(clientId1)
+	t.Run("Get client config by alias", func(t *testing.T) {
+		clientId, _, rule, err := workspace.GetClient("", "ŁÓDŹ sp. z o.o.")
 
 		assert.Errors(t, err, nil)
-		assert.Strings(t, client.JiraClientUser, client.JiraClientUser)
+		assert.Strings(t, clientId, "lodz")
+		assert.Strings(t, rule, ClientMatchAlias)
 	})
 
 	t.Run("Throw error if client not configured", func(t *testing.T) {
@@ -144,12 +164,57 @@ func TestGetClient(t *testing.T) {
 			Clients: nil,
 		}
 
-		_, err := workspace.GetClient("invalid-client-id")
+		_, _, _, err := workspace.GetClient("", "invalid-client-id")
 
 		assert.Errors(t, err, ErrClientNotFound)
 	})
 }
 
+func TestNormalizeClientName(t *testing.T) {
+	assert.Strings(t, NormalizeClientName("Łódź Sp. z o.o."), "lodz_sp_z_o_o")
+	assert.Strings(t, NormalizeClientName(" Müller & Söhne GmbH "), "muller_sohne_gmbh")
+	assert.Strings(t, NormalizeClientName("client_1"), "client_1")
+}
+
+func TestFindClientByJiraHost(t *testing.T) {
+
+	workspace := Workspace{
+		Clients: Clients{
+			clientId1: &Client{JiraHost: "https://acme.atlassian.net"},
+			clientId2: &Client{JiraHost: "https://other.atlassian.net/"},
+			clientId3: &Client{JiraHost: "https://other.atlassian.net"},
+		},
+	}
+
+	t.Run("Find client by jira host", func(t *testing.T) {
+		id, client, err := workspace.FindClientByJiraHost("https://ACME.atlassian.net", "")
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, id, clientId1)
+		assert.Strings(t, client.JiraHost, "https://acme.atlassian.net")
+	})
+
+	t.Run("Prefer given client when jira host is shared", func(t *testing.T) {
+		id, _, err := workspace.FindClientByJiraHost("http://other.atlassian.net", clientId3)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, id, clientId3)
+	})
+
+	t.Run("Pick first client by id when jira host is shared", func(t *testing.T) {
+		id, _, err := workspace.FindClientByJiraHost("https://other.atlassian.net", clientId1)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, id, clientId2)
+	})
+
+	t.Run("Throw error if no client has given jira host", func(t *testing.T) {
+		_, _, err := workspace.FindClientByJiraHost("https://unknown.atlassian.net", clientId1)
+
+		assert.Errors(t, err, ErrClientForJiraHostNotFound)
+	})
+}
+
 func TestOverwriteWorkspaceClientsPrecisionConfig(t *testing.T) {
 	workspace := Workspace{
 		Clients: Clients{
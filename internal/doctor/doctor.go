@@ -0,0 +1,104 @@
+package doctor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"text/template"
+)
+
+const (
+	StatusOk      = "ok"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+
+	FormatText = "text"
+	FormatJson = "json"
+
+	reportTemplate = `------
+DOCTOR
+------
+{{- range .Checks}}
+{{printf "[%-7s] %-30s %-28s %s" .Status .Scope .Name .Detail}}
+{{- end}}
+------
+{{.Passed}} passed, {{.Failed}} failed, {{.Skipped}} skipped
+`
+)
+
+// Check is a single item of the checklist
+type Check struct {
+	Scope  string `json:"scope"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type Report struct {
+	Checks  []Check `json:"checks"`
+	Passed  int     `json:"passed"`
+	Failed  int     `json:"failed"`
+	Skipped int     `json:"skipped"`
+}
+
+// Add records check result, error message is the detail of failed check
+func (r *Report) Add(scope, name string, err error, detail string) bool {
+
+	if err != nil {
+		r.add(Check{Scope: scope, Name: name, Status: StatusFailed, Detail: err.Error()})
+
+		return false
+	}
+
+	r.add(Check{Scope: scope, Name: name, Status: StatusOk, Detail: detail})
+
+	return true
+}
+
+// Skip records check which cannot run because the check it depends on failed
+func (r *Report) Skip(scope, name, reason string) {
+	r.add(Check{Scope: scope, Name: name, Status: StatusSkipped, Detail: reason})
+}
+
+func (r *Report) HasFailures() bool {
+	return r.Failed != 0
+}
+
+// GetReport renders checklist as text or json
+func (r *Report) GetReport(format string) (string, error) {
+
+	if format == FormatJson {
+		data, err := json.MarshalIndent(r, "", "  ")
+
+		return string(data) + "\n", err
+	}
+
+	var output bytes.Buffer
+
+	t := template.Must(template.New("doctor").Parse(reportTemplate))
+
+	if err := t.Execute(&output, r); err != nil {
+		return "", err
+	}
+
+	return output.String(), nil
+}
+
+func (r *Report) add(check Check) {
+
+	r.Checks = append(r.Checks, check)
+
+	switch check.Status {
+	case StatusOk:
+		r.Passed++
+	case StatusFailed:
+		r.Failed++
+	default:
+		r.Skipped++
+	}
+}
+
+// SkipReason explains skipped checks
+func SkipReason(failedCheck string) string {
+	return fmt.Sprintf("%s check failed", failedCheck)
+}
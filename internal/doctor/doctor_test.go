@@ -0,0 +1,58 @@
+package doctor
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+func newReport() *Report {
+
+	report := &Report{}
+	report.Add("clockify", "token", nil, "John Doe")
+	report.Add("ws_1/acme", "authentication", errors.New("Jira authentication failed"), "")
+	report.Skip("ws_1/acme", "worklog permission ABC", SkipReason("authentication"))
+
+	return report
+}
+
+func TestReport(t *testing.T) {
+
+	t.Run("Count check results", func(t *testing.T) {
+		report := newReport()
+
+		assert.Ints(t, report.Passed, 1)
+		assert.Ints(t, report.Failed, 1)
+		assert.Ints(t, report.Skipped, 1)
+		assert.Bools(t, report.HasFailures(), true)
+		assert.Bools(t, (&Report{}).HasFailures(), false)
+	})
+
+	t.Run("Render text checklist", func(t *testing.T) {
+		output, err := newReport().GetReport(FormatText)
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, strings.Contains(output, "[ok     ] clockify"), true)
+		assert.Bools(t, strings.Contains(output, "Jira authentication failed"), true)
+		assert.Bools(t, strings.Contains(output, "authentication check failed"), true)
+		assert.Bools(t, strings.HasSuffix(output, "1 passed, 1 failed, 1 skipped\n"), true)
+	})
+
+	t.Run("Render json", func(t *testing.T) {
+		output, err := newReport().GetReport(FormatJson)
+
+		assert.Errors(t, err, nil)
+
+		report := Report{}
+		err = json.Unmarshal([]byte(output), &report)
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, len(report.Checks), 3)
+		assert.Strings(t, report.Checks[1].Status, StatusFailed)
+		assert.Strings(t, report.Checks[1].Detail, "Jira authentication failed")
+		assert.Ints(t, report.Failed, 1)
+	})
+}
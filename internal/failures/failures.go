@@ -0,0 +1,106 @@
+package failures
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	FileName = "failures.yaml"
+
+	ErrFailuresFileInvalid = FailuresErr("Cannot read migration failures file")
+	ErrFailuresFileWrite   = FailuresErr("Cannot write migration failures file")
+)
+
+type FailuresErr string
+
+func (e FailuresErr) Error() string {
+	return string(e)
+}
+
+// Failure is the last failed migration of a time entry
+type Failure struct {
+	IssueID     string    `yaml:"issue_id"`
+	Reason      string    `yaml:"reason"`
+	Attempts    int       `yaml:"attempts"`
+	LastAttempt time.Time `yaml:"last_attempt"`
+}
+
+// Store keeps failed migrations by time entry id, so failures can be explained and retries limited
+type Store struct {
+	path     string
+	failures map[string]Failure
+}
+
+// Load reads failures file, missing file is an empty store
+func Load(path string) (*Store, error) {
+
+	store := &Store{path: path, failures: map[string]Failure{}}
+	data, err := os.ReadFile(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+
+	if err != nil || yaml.Unmarshal(data, &store.failures) != nil {
+		return nil, ErrFailuresFileInvalid
+	}
+
+	if store.failures == nil {
+		store.failures = map[string]Failure{}
+	}
+
+	return store, nil
+}
+
+func (s *Store) Get(timeEntryID string) (Failure, bool) {
+
+	failure, ok := s.failures[timeEntryID]
+
+	return failure, ok
+}
+
+// Record increases number of attempts and keeps the last reason
+func (s *Store) Record(timeEntryID, issueID, reason string, now time.Time) {
+
+	failure := s.failures[timeEntryID]
+	failure.IssueID = issueID
+	failure.Reason = reason
+	failure.Attempts++
+	failure.LastAttempt = now
+
+	s.failures[timeEntryID] = failure
+}
+
+func (s *Store) Clear(timeEntryID string) {
+
+	delete(s.failures, timeEntryID)
+}
+
+func (s *Store) Save() error {
+
+	data, err := yaml.Marshal(s.failures)
+
+	if err != nil {
+		return ErrFailuresFileWrite
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return ErrFailuresFileWrite
+	}
+
+	// failure reasons contain raw jira responses - readable only by the owner
+	if err := os.WriteFile(s.path, data, 0600); err != nil {
+		return ErrFailuresFileWrite
+	}
+
+	if err := os.Chmod(s.path, 0600); err != nil {
+		return ErrFailuresFileWrite
+	}
+
+	return nil
+}
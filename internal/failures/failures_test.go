@@ -0,0 +1,66 @@
+package failures
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+func TestStore(t *testing.T) {
+
+	now := time.Date(2025, time.January, 13, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Load missing file as empty store", func(t *testing.T) {
+		store, err := Load(filepath.Join(t.TempDir(), FileName))
+
+		assert.Errors(t, err, nil)
+
+		_, ok := store.Get("id1")
+
+		assert.Bools(t, ok, false)
+	})
+
+	t.Run("Record attempts and keep them after save", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "state", FileName)
+		store, _ := Load(path)
+
+		store.Record("id1", "ABC-1", "issue does not exist", now)
+		store.Record("id1", "ABC-1", "worklog permission missing", now.Add(time.Hour))
+		store.Record("id2", "ABC-2", "timeout", now)
+		store.Clear("id2")
+
+		assert.Errors(t, store.Save(), nil)
+
+		loaded, err := Load(path)
+
+		assert.Errors(t, err, nil)
+
+		failure, ok := loaded.Get("id1")
+
+		assert.Bools(t, ok, true)
+		assert.Ints(t, failure.Attempts, 2)
+		assert.Strings(t, failure.IssueID, "ABC-1")
+		assert.Strings(t, failure.Reason, "worklog permission missing")
+		assert.Bools(t, failure.LastAttempt.Equal(now.Add(time.Hour)), true)
+
+		_, ok = loaded.Get("id2")
+
+		assert.Bools(t, ok, false)
+
+		info, _ := os.Stat(path)
+
+		assert.Strings(t, info.Mode().Perm().String(), "-rw-------")
+	})
+
+	t.Run("Return error on invalid file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), FileName)
+		os.WriteFile(path, []byte("id1: [invalid"), 0644)
+
+		_, err := Load(path)
+
+		assert.Errors(t, err, ErrFailuresFileInvalid)
+	})
+}
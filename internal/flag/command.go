@@ -0,0 +1,159 @@
+package flag
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// command is a subcommand with its own flags and validators
+type command struct {
+	name        []string
+	description string
+	defineFlags []func(flagSet *pflag.FlagSet, flag *Flag)
+	validators  flagValidators
+}
+
+var (
+	CommandMigrate        = []string{"migrate"}
+	CommandStatus         = []string{"status"}
+	CommandReportDrift    = []string{"report", "drift"}
+	CommandDoctor         = []string{"doctor"}
+	CommandConfigValidate = []string{"config", "validate"}
+	CommandConfigInit     = []string{"config", "init"}
+	CommandConfigMigrate  = []string{"config", "migrate"}
+	CommandConfigShow     = []string{"config", "show"}
+	CommandConfigSchema   = []string{"config", "schema"}
+
+	commands = []command{
+		{
+			name:        CommandMigrate,
+			description: "Migrate clockify time entries to jira worklogs (default command)",
+			defineFlags: []func(*pflag.FlagSet, *Flag){defineSelectionFlags, defineMigrateFlags},
+			validators:  flagValidators{applyFlagValidator, interactiveFlagValidator, periodFlagValidator, dateRangeFlagValidator, entryFlagValidator, retryFailedFlagValidator},
+		},
+		{
+			name:        CommandStatus,
+			description: "Count time entries of the period by migration state",
+			defineFlags: []func(*pflag.FlagSet, *Flag){defineSelectionFlags},
+			validators:  flagValidators{periodFlagValidator, dateRangeFlagValidator},
+		},
+		{
+			name:        CommandReportDrift,
+			description: "Compare rounded and actual time per client, project and week",
+			defineFlags: []func(*pflag.FlagSet, *Flag){defineSelectionFlags},
+			validators:  flagValidators{periodFlagValidator, dateRangeFlagValidator},
+		},
+		{
+			name:        CommandDoctor,
+			description: "Check clockify token, workspaces, tags and jira access of enabled clients",
+			defineFlags: []func(*pflag.FlagSet, *Flag){defineDoctorFlags},
+			validators:  flagValidators{formatFlagValidator},
+		},
+		{
+			name:        CommandConfigValidate,
+			description: "List every configuration problem",
+		},
+		{
+			name:        CommandConfigInit,
+			description: "Write configuration template",
+			defineFlags: []func(*pflag.FlagSet, *Flag){defineConfigInitFlags},
+		},
+		{
+			name:        CommandConfigMigrate,
+			description: "Upgrade configuration to the current layout",
+			defineFlags: []func(*pflag.FlagSet, *Flag){defineConfigMigrateFlags},
+		},
+		{
+			name:        CommandConfigShow,
+			description: "Show configuration with masked secrets",
+			defineFlags: []func(*pflag.FlagSet, *Flag){defineSelectionFlags, defineConfigShowFlags},
+			validators:  flagValidators{periodFlagValidator, dateRangeFlagValidator},
+		},
+		{
+			name:        CommandConfigSchema,
+			description: "Print JSON Schema of the configuration file",
+		},
+	}
+)
+
+// findCommand matches leading arguments with command names, arguments starting with a flag run migrate command
+func findCommand(args []string) (command, []string, error) {
+
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return commands[0], args, nil
+	}
+
+	for _, command := range commands {
+		if len(args) >= len(command.name) && slices.Equal(args[:len(command.name)], command.name) {
+			return command, args[len(command.name):], nil
+		}
+	}
+
+	return command{}, nil, ErrFlagUnknownCommand
+}
+
+func defineCommonFlags(flagSet *pflag.FlagSet, flag *Flag) {
+	flagSet.BoolVarP(&flag.Help, "help", "h", false, "Display help")
+	flagSet.StringVar(&flag.ConfigFilePath, "config", "~/.clockify-to-jira/config.yaml", "Config file path")
+}
+
+func defineSelectionFlags(flagSet *pflag.FlagSet, flag *Flag) {
+	flagSet.StringSliceVarP(&flag.Workspaces, "workspace", "w", []string{}, "Filter by workspaceId")
+	flagSet.StringSliceVarP(&flag.Clients, "client", "c", []string{}, "Filter by clientId")
+
+	flagSet.IntVarP(&flag.Period, "period", "p", 7, "Migrate time entries from last given days (and today)")
+	flagSet.StringVar(&flag.From, "from", "", "Start date - YYYY-MM-DD, YYYY-MM-DDTHH:MM, today, yesterday, this-week, last-week, this-month, last-month or weekday")
+	flagSet.StringVar(&flag.To, "to", "", "End date (included) - same formats as --from (default now)")
+	flagSet.IntVarP(&flag.Precision, "tryb-niepokorny", "t", 15, "Rounding up the value of logged time up (minutes)")
+}
+
+func defineMigrateFlags(flagSet *pflag.FlagSet, flag *Flag) {
+	flagSet.BoolVarP(&flag.Apply, "apply", "a", false, "Update jira tasks workload")
+	flagSet.BoolVarP(&flag.Debug, "debug", "d", false, "Debug mode - Include already logged time entries")
+	flagSet.BoolVarP(&flag.Version, "version", "v", false, "Show build detials")
+	flagSet.BoolVarP(&flag.Interactive, "interactive", "i", false, "Interactive mode - review every worklog before applying")
+	flagSet.StringSliceVar(&flag.Entries, "entry", []string{}, "Migrate only given clockify time entry ids (instead of period)")
+	flagSet.BoolVar(&flag.RetryFailed, "retry-failed", false, "Migrate only time entries tagged as failed of the configured period, widen it with -p or --from")
+	flagSet.StringVar(&flag.ResultFile, "result-file", "", "Write JSON run result with worklog counts per workspace and client to given file")
+	flagSet.StringSliceVar(&flag.Issues, "issue", []string{}, "Migrate only time entries of given jira issues, e.g. ABC-12")
+}
+
+func defineDoctorFlags(flagSet *pflag.FlagSet, flag *Flag) {
+	flagSet.StringSliceVarP(&flag.Workspaces, "workspace", "w", []string{}, "Filter by workspaceId")
+	flagSet.StringSliceVarP(&flag.Clients, "client", "c", []string{}, "Filter by clientId")
+	flagSet.StringVar(&flag.Format, "format", "text", "Output format - text or json")
+}
+
+func defineConfigInitFlags(flagSet *pflag.FlagSet, flag *Flag) {
+	flagSet.BoolVarP(&flag.Interactive, "interactive", "i", false, "Interactive mode - wizard based on clockify workspaces and clients")
+}
+
+func defineConfigMigrateFlags(flagSet *pflag.FlagSet, flag *Flag) {
+	flagSet.BoolVar(&flag.Write, "write", false, "Rewrite configuration file (backup is created)")
+}
+
+func defineConfigShowFlags(flagSet *pflag.FlagSet, flag *Flag) {
+	flagSet.BoolVar(&flag.Resolved, "resolved", false, "Display effective client settings and their source")
+}
+
+// printUsage writes command flags, migrate command lists other commands as well
+func printUsage(output io.Writer, program string, command command, flagSet *pflag.FlagSet) {
+
+	if slices.Equal(command.name, CommandMigrate) {
+		fmt.Fprintf(output, "Usage: %s [command] [flags]\n\nCommands:\n", program)
+
+		for _, command := range commands {
+			fmt.Fprintf(output, "  %-18s %s\n", strings.Join(command.name, " "), command.description)
+		}
+
+		fmt.Fprintf(output, "\nRun %s [command] -h to list command flags\n\n", program)
+	} else {
+		fmt.Fprintf(output, "Usage: %s %s [flags]\n\n%s\n\n", program, strings.Join(command.name, " "), command.description)
+	}
+
+	fmt.Fprintf(output, "Flags:\n%s", flagSet.FlagUsages())
+}
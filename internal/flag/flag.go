@@ -1,7 +1,11 @@
 package flag
 
 import (
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/spf13/pflag"
@@ -10,14 +14,26 @@ import (
 type Flag struct {
 	Apply          bool
 	Clients        []string
+	Command        []string
 	ConfigFilePath string
 	Debug          bool
+	Entries        []string
+	Format         string
+	From           string
 	Help           bool
+	Interactive    bool
+	Issues         []string
+	IsSet          func(name string) bool
 	Period         int
 	Precision      int
 	PrintDefaults  func()
+	Resolved       bool
+	ResultFile     string
+	RetryFailed    bool
+	To             string
 	Version        bool
 	Workspaces     []string
+	Write          bool
 }
 
 const (
@@ -30,35 +46,49 @@ func (e FlagErr) Error() string {
 	return string(e)
 }
 
+// InitializeFlags parses command given as leading arguments and its flags.
+// Arguments without command (e.g. -p 3 --apply) run migrate command.
 func InitializeFlags(args []string) (Flag, error) {
-	flagSet := pflag.NewFlagSet(args[0], pflag.ExitOnError)
+
+	program := filepath.Base(args[0])
+	command, commandArgs, err := findCommand(args[1:])
+
+	if err != nil {
+		return Flag{}, err
+	}
+
+	flagSet := pflag.NewFlagSet(program, pflag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
 
 	flag := Flag{
-		PrintDefaults: flagSet.PrintDefaults,
+		Command:       command.name,
+		PrintDefaults: func() { printUsage(os.Stderr, program, command, flagSet) },
+		IsSet:         flagSet.Changed,
 	}
 
-	flagSet.BoolVarP(&flag.Apply, "apply", "a", false, "Update jira tasks workload")
-	flagSet.BoolVarP(&flag.Debug, "debug", "d", false, "Debug mode - Include already logged time entries")
-	flagSet.BoolVarP(&flag.Help, "help", "h", false, "Display help")
-	flagSet.BoolVarP(&flag.Version, "version", "v", false, "Show build detials")
+	defineCommonFlags(flagSet, &flag)
 
-	flagSet.StringVar(&flag.ConfigFilePath, "config", "~/.clockify-to-jira/config.yaml", "Config file path")
+	for _, defineFlags := range command.defineFlags {
+		defineFlags(flagSet, &flag)
+	}
 
-	flagSet.StringSliceVarP(&flag.Workspaces, "workspace", "w", []string{}, "Filter by workspaceId")
-	flagSet.StringSliceVarP(&flag.Clients, "client", "c", []string{}, "Filter by clientId")
+	err = flagSet.Parse(commandArgs)
 
-	flagSet.IntVarP(&flag.Period, "period", "p", 7, "Migrate time entries from last given days")
-	flagSet.IntVarP(&flag.Precision, "tryb-niepokorny", "t", 15, "Rounding up the value of logged time up (minutes)")
+	if err != nil {
+		return Flag{}, fmt.Errorf("%w - %v", ErrFlagInvalid, err)
+	}
 
-	flagSet.Parse(args[1:])
+	if flagSet.NArg() != 0 {
+		return Flag{}, ErrFlagUnknownCommand
+	}
 
-	err := flag.convertConfigFilePathToAbsolute()
+	err = flag.convertConfigFilePathToAbsolute()
 
 	if err != nil {
 		return Flag{}, err
 	}
 
-	err = flag.validateFlags()
+	err = command.validators.validate(flag)
 
 	if err != nil {
 		return Flag{}, err
@@ -67,6 +97,42 @@ func InitializeFlags(args []string) (Flag, error) {
 	return flag, nil
 }
 
+func (f Flag) IsMigrate() bool {
+	return slices.Equal(f.Command, CommandMigrate)
+}
+
+func (f Flag) IsStatus() bool {
+	return slices.Equal(f.Command, CommandStatus)
+}
+
+func (f Flag) IsDoctor() bool {
+	return slices.Equal(f.Command, CommandDoctor)
+}
+
+func (f Flag) IsDriftReport() bool {
+	return slices.Equal(f.Command, CommandReportDrift)
+}
+
+func (f Flag) IsConfigValidate() bool {
+	return slices.Equal(f.Command, CommandConfigValidate)
+}
+
+func (f Flag) IsConfigInit() bool {
+	return slices.Equal(f.Command, CommandConfigInit)
+}
+
+func (f Flag) IsConfigMigrate() bool {
+	return slices.Equal(f.Command, CommandConfigMigrate)
+}
+
+func (f Flag) IsConfigShow() bool {
+	return slices.Equal(f.Command, CommandConfigShow)
+}
+
+func (f Flag) IsConfigSchema() bool {
+	return slices.Equal(f.Command, CommandConfigSchema)
+}
+
 func (f *Flag) convertConfigFilePathToAbsolute() error {
 	dirname, err := os.UserHomeDir()
 
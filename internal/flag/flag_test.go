@@ -29,6 +29,7 @@ func TestInitializeFlagsSuccess(t *testing.T) {
 		assert.Bools(t, flag.Debug, false)
 		assert.Bools(t, flag.Version, false)
 		assert.Bools(t, flag.Apply, false)
+		assert.Bools(t, flag.Interactive, false)
 		assert.Ints(t, flag.Precision, 15)
 		assert.Ints(t, flag.Period, 7)
 		assert.Strings(t, flag.ConfigFilePath, "/home/user/.clockify-to-jira/config.yaml")
@@ -78,6 +79,8 @@ func TestInitializeFlagsSuccess(t *testing.T) {
 		assert.Ints(t, flag.Period, 31)
 		assert.Ints(t, flag.Precision, 30)
 		assert.Bools(t, flag.Version, true)
+		assert.Bools(t, flag.IsSet("period"), true)
+		assert.Bools(t, flag.IsSet("apply"), false)
 		assert.StringSlices(t, flag.Workspaces, []string{"workspaceId"})
 		assert.StringSlices(t, flag.Clients, []string{"clientId"})
 	})
@@ -145,6 +148,315 @@ func TestValidateFlags(t *testing.T) {
 
 		assert.Errors(t, err, ErrFlagPeriodLessThanOne)
 	})
+
+	t.Run("Accept report drift command", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		args := []string{
+			os.Args[0],
+			"report",
+			"drift",
+			"-p",
+			"30",
+		}
+
+		flag, err := InitializeFlags(args)
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, flag.IsDriftReport(), true)
+		assert.Ints(t, flag.Period, 30)
+	})
+
+	t.Run("Accept config validate command", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		args := []string{
+			os.Args[0],
+			"config",
+			"validate",
+		}
+
+		flag, err := InitializeFlags(args)
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, flag.IsConfigValidate(), true)
+		assert.Bools(t, flag.IsDriftReport(), false)
+	})
+
+	t.Run("Accept interactive config init command", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		args := []string{
+			os.Args[0],
+			"config",
+			"init",
+			"-i",
+		}
+
+		flag, err := InitializeFlags(args)
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, flag.IsConfigInit(), true)
+		assert.Bools(t, flag.Interactive, true)
+	})
+
+	t.Run("Accept config migrate command", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		args := []string{
+			os.Args[0],
+			"config",
+			"migrate",
+			"--write",
+		}
+
+		flag, err := InitializeFlags(args)
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, flag.IsConfigMigrate(), true)
+		assert.Bools(t, flag.Write, true)
+	})
+
+	t.Run("Accept config show command", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		args := []string{
+			os.Args[0],
+			"config",
+			"show",
+			"--resolved",
+		}
+
+		flag, err := InitializeFlags(args)
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, flag.IsConfigShow(), true)
+		assert.Bools(t, flag.Resolved, true)
+	})
+
+	t.Run("Parse config schema command", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		args := []string{
+			os.Args[0],
+			"config",
+			"schema",
+		}
+
+		flag, err := InitializeFlags(args)
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, flag.IsConfigSchema(), true)
+	})
+
+	t.Run("Accept entries and issues", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		flag, err := InitializeFlags([]string{os.Args[0], "migrate", "--entry", "id1", "--entry", "id2", "--issue", "ABC-12,XYZ-1", "-a"})
+
+		assert.Errors(t, err, nil)
+		assert.StringSlices(t, flag.Entries, []string{"id1", "id2"})
+		assert.StringSlices(t, flag.Issues, []string{"ABC-12", "XYZ-1"})
+		assert.Bools(t, flag.Apply, true)
+	})
+
+	t.Run("Return error if entry is used with period or date range", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		_, err := InitializeFlags([]string{os.Args[0], "--entry", "id1", "-p", "3"})
+
+		assert.Errors(t, err, ErrFlagEntryRangeConflict)
+
+		_, err = InitializeFlags([]string{os.Args[0], "--entry", "id1", "--from", "monday"})
+
+		assert.Errors(t, err, ErrFlagEntryRangeConflict)
+	})
+
+	t.Run("Accept interactive migration", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		flag, err := InitializeFlags([]string{os.Args[0], "-a", "-i"})
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, flag.Interactive, true)
+		assert.Bools(t, flag.IsMigrate(), true)
+	})
+
+	t.Run("Return error if interactive is used without apply", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		_, err := InitializeFlags([]string{os.Args[0], "migrate", "--interactive"})
+
+		assert.Errors(t, err, ErrFlagInteractiveNoApply)
+	})
+
+	t.Run("Accept retry failed with date range", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		flag, err := InitializeFlags([]string{os.Args[0], "migrate", "--retry-failed", "--from", "2025-01-01"})
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, flag.RetryFailed, true)
+		assert.Strings(t, flag.From, "2025-01-01")
+	})
+
+	t.Run("Return error if retry failed is used with entry", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		_, err := InitializeFlags([]string{os.Args[0], "--retry-failed", "--entry", "id1"})
+
+		assert.Errors(t, err, ErrFlagRetryEntryConflict)
+	})
+
+	t.Run("Parse result file", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		flag, err := InitializeFlags([]string{os.Args[0], "migrate", "--result-file", "/tmp/result.json"})
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, flag.ResultFile, "/tmp/result.json")
+	})
+
+	t.Run("Return error on unknown command", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		args := []string{
+			os.Args[0],
+			"report",
+			"unknown",
+		}
+
+		_, err := InitializeFlags(args)
+
+		assert.Errors(t, err, ErrFlagUnknownCommand)
+	})
+
+	t.Run("Return error if flag of other command is used", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		args := []string{
+			os.Args[0],
+			"report",
+			"drift",
+			"-a",
+		}
+
+		_, err := InitializeFlags(args)
+
+		assert.ErrorsIs(t, err, ErrFlagInvalid)
+	})
+
+	t.Run("Return error on arguments after command", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		args := []string{
+			os.Args[0],
+			"status",
+			"now",
+		}
+
+		_, err := InitializeFlags(args)
+
+		assert.Errors(t, err, ErrFlagUnknownCommand)
+	})
+}
+
+func TestInitializeCommands(t *testing.T) {
+
+	t.Run("Run migrate command when only flags are given", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		flag, err := InitializeFlags([]string{os.Args[0], "-p", "3", "--apply"})
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, flag.IsMigrate(), true)
+		assert.Bools(t, flag.Apply, true)
+		assert.Ints(t, flag.Period, 3)
+	})
+
+	t.Run("Accept migrate command", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		flag, err := InitializeFlags([]string{os.Args[0], "migrate", "-p", "3", "--apply"})
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, flag.IsMigrate(), true)
+		assert.Bools(t, flag.Apply, true)
+	})
+
+	t.Run("Accept doctor command", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		flag, err := InitializeFlags([]string{os.Args[0], "doctor", "-c", "acme", "--format", "json"})
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, flag.IsDoctor(), true)
+		assert.StringSlices(t, flag.Clients, []string{"acme"})
+		assert.Strings(t, flag.Format, "json")
+	})
+
+	t.Run("Return error on unknown doctor format", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		_, err := InitializeFlags([]string{os.Args[0], "doctor", "--format", "yaml"})
+
+		assert.Errors(t, err, ErrFlagInvalidFormat)
+	})
+
+	t.Run("Accept status command", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		flag, err := InitializeFlags([]string{os.Args[0], "status", "-w", "ws_1", "-p", "14"})
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, flag.IsStatus(), true)
+		assert.StringSlices(t, flag.Workspaces, []string{"ws_1"})
+		assert.Ints(t, flag.Period, 14)
+	})
+
+	t.Run("Validate flags of command only", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		flag, err := InitializeFlags([]string{os.Args[0], "config", "validate"})
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, flag.IsConfigValidate(), true)
+		assert.Ints(t, flag.Period, 0)
+	})
+
+	t.Run("Accept date range", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		flag, err := InitializeFlags([]string{os.Args[0], "--from", "2025-01-01", "--to", "last-month"})
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, flag.From, "2025-01-01")
+		assert.Strings(t, flag.To, "last-month")
+	})
+
+	t.Run("Return error if to is used without from", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		_, err := InitializeFlags([]string{os.Args[0], "status", "--to", "today"})
+
+		assert.Errors(t, err, ErrFlagToWithoutFrom)
+	})
+
+	t.Run("Return error if period is used with date range", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		_, err := InitializeFlags([]string{os.Args[0], "report", "drift", "-p", "3", "--from", "monday"})
+
+		assert.Errors(t, err, ErrFlagPeriodDateConflict)
+	})
+
+	t.Run("Return error on unknown command", func(t *testing.T) {
+		initFlagTestsHomeEnvVariable(t)
+
+		_, err := InitializeFlags([]string{os.Args[0], "deploy"})
+
+		assert.Errors(t, err, ErrFlagUnknownCommand)
+	})
 }
 
 func TestError(t *testing.T) {
@@ -5,16 +5,19 @@ type flagValidators []func(Flag) error
 const (
 	ErrFlagApplyDebugConflict = FlagErr("Apply and debug flags cannot be set to true at the same time")
 	ErrFlagPeriodLessThanOne  = FlagErr("Period flag (-p|--period) cannot be negative")
+	ErrFlagUnknownCommand     = FlagErr("Unknown command - run with -h to list commands")
+	ErrFlagInvalid            = FlagErr("Invalid flag - run command with -h to list its flags")
+	ErrFlagToWithoutFrom      = FlagErr("To flag (--to) requires from flag (--from)")
+	ErrFlagPeriodDateConflict = FlagErr("Period flag (-p|--period) cannot be used with date range (--from)")
+	ErrFlagInteractiveNoApply = FlagErr("Interactive flag (-i|--interactive) requires apply flag (-a|--apply)")
+	ErrFlagRetryEntryConflict = FlagErr("Retry failed flag (--retry-failed) cannot be used with entry flag (--entry)")
+	ErrFlagInvalidFormat      = FlagErr("Format flag (--format) has to be text or json")
+	ErrFlagEntryRangeConflict = FlagErr("Entry flag (--entry) cannot be used with period (-p|--period) or date range (--from)")
 )
 
-func (f Flag) validateFlags() error {
+func (v flagValidators) validate(f Flag) error {
 
-	flagValidatorList := flagValidators{
-		applyFlagValidator,
-		periodFlagValidator,
-	}
-
-	for _, validator := range flagValidatorList {
+	for _, validator := range v {
 		err := validator(f)
 
 		if err != nil {
@@ -35,6 +38,15 @@ func applyFlagValidator(f Flag) error {
 	return nil
 }
 
+func interactiveFlagValidator(f Flag) error {
+
+	if f.Interactive && !f.Apply {
+		return ErrFlagInteractiveNoApply
+	}
+
+	return nil
+}
+
 func periodFlagValidator(f Flag) error {
 
 	if f.Period < 1 {
@@ -43,3 +55,43 @@ func periodFlagValidator(f Flag) error {
 
 	return nil
 }
+
+func dateRangeFlagValidator(f Flag) error {
+
+	if f.To != "" && f.From == "" {
+		return ErrFlagToWithoutFrom
+	}
+
+	if f.From != "" && f.IsSet("period") {
+		return ErrFlagPeriodDateConflict
+	}
+
+	return nil
+}
+
+func entryFlagValidator(f Flag) error {
+
+	if len(f.Entries) != 0 && (f.From != "" || f.IsSet("period")) {
+		return ErrFlagEntryRangeConflict
+	}
+
+	return nil
+}
+
+func retryFailedFlagValidator(f Flag) error {
+
+	if f.RetryFailed && len(f.Entries) != 0 {
+		return ErrFlagRetryEntryConflict
+	}
+
+	return nil
+}
+
+func formatFlagValidator(f Flag) error {
+
+	if f.Format != "text" && f.Format != "json" {
+		return ErrFlagInvalidFormat
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Atlassian Document Format - https://developer.atlassian.com/cloud/jira/platform/apis/document/structure/
+type Document struct {
+	Version int    `json:"version"`
+	Type    string `json:"type"`
+	Content []Node `json:"content"`
+}
+
+type Node struct {
+	Type    string         `json:"type"`
+	Text    string         `json:"text,omitempty"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Marks   []Mark         `json:"marks,omitempty"`
+	Content []Node         `json:"content,omitempty"`
+}
+
+type Mark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+const (
+	urlTrailingPunctuation = ".,;:!?)"
+)
+
+var (
+	inlinePattern   = regexp.MustCompile("https?://[^\\s]+|`[^`]+`|\\*\\*[^*]+\\*\\*|\\*[^*\\s][^*]*\\*|\\b[A-Z][A-Z0-9]+-[0-9]+\\b")
+	issueKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]+-[0-9]+$`)
+	bulletPattern   = regexp.MustCompile(`^\s*[-*]\s+`)
+)
+
+func ToADF(text, host string) Document {
+
+	document := Document{
+		Version: 1,
+		Type:    "doc",
+		Content: []Node{},
+	}
+
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	var paragraph, bulletList *Node
+
+	for _, line := range lines {
+
+		if strings.TrimSpace(line) == "" {
+			paragraph, bulletList = nil, nil
+			continue
+		}
+
+		if bulletPattern.MatchString(line) {
+			paragraph = nil
+
+			if bulletList == nil {
+				document.Content = append(document.Content, Node{Type: "bulletList"})
+				bulletList = &document.Content[len(document.Content)-1]
+			}
+
+			listItem := Node{
+				Type: "listItem",
+				Content: []Node{
+					{Type: "paragraph", Content: parseInline(bulletPattern.ReplaceAllString(line, ""), host)},
+				},
+			}
+			bulletList.Content = append(bulletList.Content, listItem)
+
+			continue
+		}
+
+		bulletList = nil
+
+		if paragraph == nil {
+			document.Content = append(document.Content, Node{Type: "paragraph"})
+			paragraph = &document.Content[len(document.Content)-1]
+		} else {
+			paragraph.Content = append(paragraph.Content, Node{Type: "hardBreak"})
+		}
+
+		paragraph.Content = append(paragraph.Content, parseInline(strings.TrimSpace(line), host)...)
+	}
+
+	return document
+}
+
+func parseInline(text, host string) []Node {
+
+	nodes := []Node{}
+	position := 0
+
+	for _, match := range inlinePattern.FindAllStringIndex(text, -1) {
+		start, end := match[0], match[1]
+
+		if start < position {
+			continue
+		}
+
+		nodes = appendText(nodes, text[position:start])
+
+		token := text[start:end]
+
+		switch {
+		case strings.HasPrefix(token, "http"):
+			url := strings.TrimRight(token, urlTrailingPunctuation)
+			end = start + len(url)
+			nodes = append(nodes, textNode(url, Mark{Type: "link", Attrs: map[string]any{"href": url}}))
+		case strings.HasPrefix(token, "`"):
+			nodes = append(nodes, textNode(strings.Trim(token, "`"), Mark{Type: "code"}))
+		case strings.HasPrefix(token, "**"):
+			nodes = append(nodes, textNode(strings.Trim(token, "*"), Mark{Type: "strong"}))
+		case strings.HasPrefix(token, "*"):
+			nodes = append(nodes, textNode(strings.Trim(token, "*"), Mark{Type: "em"}))
+		case issueKeyPattern.MatchString(token) && host != "":
+			nodes = append(nodes, Node{
+				Type:  "inlineCard",
+				Attrs: map[string]any{"url": fmt.Sprintf("%s/browse/%s", host, token)},
+			})
+		default:
+			nodes = appendText(nodes, token)
+		}
+
+		position = end
+	}
+
+	return appendText(nodes, text[position:])
+}
+
+func appendText(nodes []Node, text string) []Node {
+
+	if text == "" {
+		return nodes
+	}
+
+	return append(nodes, Node{Type: "text", Text: text})
+}
+
+func textNode(text string, mark Mark) Node {
+	return Node{Type: "text", Text: text, Marks: []Mark{mark}}
+}
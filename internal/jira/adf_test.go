@@ -0,0 +1,93 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+func toJson(t testing.TB, document Document) string {
+	t.Helper()
+
+	data, err := json.Marshal(document)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(data)
+}
+
+func TestToADF(t *testing.T) {
+
+	t.Run("Convert plain text", func(t *testing.T) {
+		got := toJson(t, ToADF("Some description", "https://acme.atlassian.net"))
+		want := `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"Some description"}]}]}`
+
+		assert.Strings(t, got, want)
+	})
+
+	t.Run("Convert empty comment", func(t *testing.T) {
+		got := toJson(t, ToADF("", "https://acme.atlassian.net"))
+		want := `{"version":1,"type":"doc","content":[]}`
+
+		assert.Strings(t, got, want)
+	})
+
+	t.Run("Convert line breaks and paragraphs", func(t *testing.T) {
+		got := toJson(t, ToADF("line 1\nline 2\n\nline 3", ""))
+		want := `{"version":1,"type":"doc","content":[` +
+			`{"type":"paragraph","content":[{"type":"text","text":"line 1"},{"type":"hardBreak"},{"type":"text","text":"line 2"}]},` +
+			`{"type":"paragraph","content":[{"type":"text","text":"line 3"}]}]}`
+
+		assert.Strings(t, got, want)
+	})
+
+	t.Run("Convert urls to links", func(t *testing.T) {
+		got := toJson(t, ToADF("see https://example.com/a?b=c.", ""))
+		want := `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[` +
+			`{"type":"text","text":"see "},` +
+			`{"type":"text","text":"https://example.com/a?b=c","marks":[{"type":"link","attrs":{"href":"https://example.com/a?b=c"}}]},` +
+			`{"type":"text","text":"."}]}]}`
+
+		assert.Strings(t, got, want)
+	})
+
+	t.Run("Convert issue mentions to inline cards", func(t *testing.T) {
+		got := toJson(t, ToADF("related to ABC-12", "https://acme.atlassian.net"))
+		want := `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[` +
+			`{"type":"text","text":"related to "},` +
+			`{"type":"inlineCard","attrs":{"url":"https://acme.atlassian.net/browse/ABC-12"}}]}]}`
+
+		assert.Strings(t, got, want)
+	})
+
+	t.Run("Keep issue mentions as text without host", func(t *testing.T) {
+		got := toJson(t, ToADF("ABC-12", ""))
+		want := `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"ABC-12"}]}]}`
+
+		assert.Strings(t, got, want)
+	})
+
+	t.Run("Convert basic markdown", func(t *testing.T) {
+		got := toJson(t, ToADF("**bold** *italic* `code`", ""))
+		want := `{"version":1,"type":"doc","content":[{"type":"paragraph","content":[` +
+			`{"type":"text","text":"bold","marks":[{"type":"strong"}]},{"type":"text","text":" "},` +
+			`{"type":"text","text":"italic","marks":[{"type":"em"}]},{"type":"text","text":" "},` +
+			`{"type":"text","text":"code","marks":[{"type":"code"}]}]}]}`
+
+		assert.Strings(t, got, want)
+	})
+
+	t.Run("Convert bullet list", func(t *testing.T) {
+		got := toJson(t, ToADF("done:\n- first\n* second", ""))
+		want := `{"version":1,"type":"doc","content":[` +
+			`{"type":"paragraph","content":[{"type":"text","text":"done:"}]},` +
+			`{"type":"bulletList","content":[` +
+			`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"first"}]}]},` +
+			`{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"second"}]}]}]}]}`
+
+		assert.Strings(t, got, want)
+	})
+}
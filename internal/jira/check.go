@@ -0,0 +1,109 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	gojira "github.com/andygrunwald/go-jira"
+)
+
+const (
+	ErrJiraHostUnreachable       = JiraErr("Jira host unreachable - check jira_host")
+	ErrJiraAuthenticationFailed  = JiraErr("Jira authentication failed - check jira_username and jira_password")
+	ErrJiraPermissionCheckFailed = JiraErr("Cannot check jira permissions")
+
+	permissionWorkOnIssues = "WORK_ON_ISSUES"
+)
+
+type serverInfo struct {
+	Version string `json:"version"`
+}
+
+type myself struct {
+	Name         string `json:"name"`
+	EmailAddress string `json:"emailAddress"`
+	DisplayName  string `json:"displayName"`
+}
+
+type myPermissions struct {
+	Permissions map[string]struct {
+		HavePermission bool `json:"havePermission"`
+	} `json:"permissions"`
+}
+
+// Ping checks whether jira host responds with jira server info.
+// Rejected credentials mean the host is jira - they are reported by Myself.
+func (c *Client) Ping() error {
+
+	req, err := c.client.NewRequest("GET", "rest/api/2/serverInfo", nil)
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrJiraHostUnreachable, err)
+	}
+
+	info := serverInfo{}
+	jr, err := c.client.Do(req, &info)
+
+	if jr != nil && (jr.StatusCode == http.StatusUnauthorized || jr.StatusCode == http.StatusForbidden) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrJiraHostUnreachable, gojira.NewJiraError(jr, err))
+	}
+
+	if info.Version == "" {
+		return fmt.Errorf("%w: response is not jira server info", ErrJiraHostUnreachable)
+	}
+
+	return nil
+}
+
+// Myself returns display name of the authenticated user
+func (c *Client) Myself() (string, error) {
+
+	req, err := c.client.NewRequest("GET", fmt.Sprintf("rest/api/%d/myself", c.apiVersion), nil)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrJiraAuthenticationFailed, err)
+	}
+
+	user := myself{}
+	jr, err := c.client.Do(req, &user)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrJiraAuthenticationFailed, gojira.NewJiraError(jr, err))
+	}
+
+	if user.DisplayName != "" {
+		return user.DisplayName, nil
+	}
+
+	return user.Name, nil
+}
+
+// CanLogWork checks permission to log work in the project, empty project key checks any project
+func (c *Client) CanLogWork(projectKey string) (bool, error) {
+
+	query := url.Values{"permissions": {permissionWorkOnIssues}}
+
+	if projectKey != "" {
+		query.Set("projectKey", projectKey)
+	}
+
+	req, err := c.client.NewRequest("GET", fmt.Sprintf("rest/api/2/mypermissions?%s", query.Encode()), nil)
+
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrJiraPermissionCheckFailed, err)
+	}
+
+	permissions := myPermissions{}
+	jr, err := c.client.Do(req, &permissions)
+
+	if err != nil {
+		return false, fmt.Errorf("%w: %w", ErrJiraPermissionCheckFailed, gojira.NewJiraError(jr, err))
+	}
+
+	return permissions.Permissions[permissionWorkOnIssues].HavePermission, nil
+}
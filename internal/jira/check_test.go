@@ -0,0 +1,135 @@
+package jira
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+func newFakeCheckServer(t *testing.T, status int, body string, query *string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if query != nil {
+			*query = r.URL.Path + "?" + r.URL.RawQuery
+		}
+
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestPing(t *testing.T) {
+
+	t.Run("Reachable host returning server info", func(t *testing.T) {
+		server := newFakeCheckServer(t, http.StatusOK, `{"version":"9.12.0"}`, nil)
+		defer server.Close()
+
+		client, _ := NewClient(server.URL, "user", "password", ApiVersion2)
+
+		assert.Errors(t, client.Ping(), nil)
+	})
+
+	t.Run("Reachable host rejecting credentials", func(t *testing.T) {
+		server := newFakeCheckServer(t, http.StatusUnauthorized, `{}`, nil)
+		defer server.Close()
+
+		client, _ := NewClient(server.URL, "user", "password", ApiVersion2)
+
+		assert.Errors(t, client.Ping(), nil)
+	})
+
+	t.Run("Return error on host which is not jira", func(t *testing.T) {
+		for _, response := range []struct {
+			status int
+			body   string
+		}{
+			{http.StatusNotFound, `{}`},
+			{http.StatusOK, `<html><body>Welcome</body></html>`},
+			{http.StatusOK, `{}`},
+		} {
+			server := newFakeCheckServer(t, response.status, response.body, nil)
+
+			client, _ := NewClient(server.URL, "user", "password", ApiVersion2)
+
+			assert.Bools(t, errors.Is(client.Ping(), ErrJiraHostUnreachable), true)
+
+			server.Close()
+		}
+	})
+
+	t.Run("Return error on unreachable host", func(t *testing.T) {
+		server := newFakeCheckServer(t, http.StatusOK, `{}`, nil)
+		server.Close()
+
+		client, _ := NewClient(server.URL, "user", "password", ApiVersion2)
+
+		assert.Bools(t, errors.Is(client.Ping(), ErrJiraHostUnreachable), true)
+	})
+}
+
+func TestMyself(t *testing.T) {
+
+	t.Run("Return display name using configured api version", func(t *testing.T) {
+		query := ""
+		server := newFakeCheckServer(t, http.StatusOK, `{"name":"jdoe","displayName":"John Doe"}`, &query)
+		defer server.Close()
+
+		client, _ := NewClient(server.URL, "user", "password", ApiVersion3)
+		name, err := client.Myself()
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, name, "John Doe")
+		assert.Strings(t, query, "/rest/api/3/myself?")
+	})
+
+	t.Run("Return error on rejected credentials", func(t *testing.T) {
+		server := newFakeCheckServer(t, http.StatusUnauthorized, `{}`, nil)
+		defer server.Close()
+
+		client, _ := NewClient(server.URL, "user", "expired", ApiVersion2)
+		_, err := client.Myself()
+
+		assert.Bools(t, errors.Is(err, ErrJiraAuthenticationFailed), true)
+	})
+}
+
+func TestCanLogWork(t *testing.T) {
+
+	t.Run("Check permission in project", func(t *testing.T) {
+		query := ""
+		server := newFakeCheckServer(t, http.StatusOK, `{"permissions":{"WORK_ON_ISSUES":{"havePermission":true}}}`, &query)
+		defer server.Close()
+
+		client, _ := NewClient(server.URL, "user", "password", ApiVersion2)
+		allowed, err := client.CanLogWork("ABC")
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, allowed, true)
+		assert.Strings(t, query, "/rest/api/2/mypermissions?permissions=WORK_ON_ISSUES&projectKey=ABC")
+	})
+
+	t.Run("Missing permission", func(t *testing.T) {
+		server := newFakeCheckServer(t, http.StatusOK, `{"permissions":{"WORK_ON_ISSUES":{"havePermission":false}}}`, nil)
+		defer server.Close()
+
+		client, _ := NewClient(server.URL, "user", "password", ApiVersion2)
+		allowed, err := client.CanLogWork("")
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, allowed, false)
+	})
+
+	t.Run("Return error on unknown project", func(t *testing.T) {
+		server := newFakeCheckServer(t, http.StatusNotFound, `{"errorMessages":["No project could be found with key 'XYZ'."]}`, nil)
+		defer server.Close()
+
+		client, _ := NewClient(server.URL, "user", "password", ApiVersion2)
+		_, err := client.CanLogWork("XYZ")
+
+		assert.Bools(t, errors.Is(err, ErrJiraPermissionCheckFailed), true)
+	})
+}
@@ -0,0 +1,131 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gojira "github.com/andygrunwald/go-jira"
+)
+
+const (
+	ApiVersion2 = 2
+	ApiVersion3 = 3
+
+	ErrJiraClientInitError        = JiraErr("Jira client init error - check your jira_host")
+	ErrJiraUnsupportedApiVersion  = JiraErr("Unsupported jira api version - use 2 (server) or 3 (cloud)")
+	ErrJiraWorklogRecordAddFailed = JiraErr("Cannot add worklog record")
+)
+
+type JiraErr string
+
+func (e JiraErr) Error() string {
+	return string(e)
+}
+
+type Worklog struct {
+	Comment          string
+	Started          time.Time
+	TimeSpentSeconds int
+}
+
+type Client struct {
+	client     *gojira.Client
+	host       string
+	apiVersion int
+}
+
+type worklogRecordV3 struct {
+	Comment          Document     `json:"comment"`
+	Started          *gojira.Time `json:"started"`
+	TimeSpentSeconds int          `json:"timeSpentSeconds"`
+}
+
+type worklogRecordV3Response struct {
+	ID string `json:"id"`
+}
+
+func NewClient(host, username, password string, apiVersion int) (*Client, error) {
+
+	if apiVersion == 0 {
+		apiVersion = ApiVersion2
+	}
+
+	if apiVersion != ApiVersion2 && apiVersion != ApiVersion3 {
+		return nil, ErrJiraUnsupportedApiVersion
+	}
+
+	tp := gojira.BasicAuthTransport{
+		Username: username,
+		Password: password,
+	}
+
+	jiraClient, err := gojira.NewClient(tp.Client(), host)
+
+	if err != nil {
+		return nil, ErrJiraClientInitError
+	}
+
+	return &Client{
+		client:     jiraClient,
+		host:       strings.TrimSuffix(host, "/"),
+		apiVersion: apiVersion,
+	}, nil
+}
+
+func (c *Client) AddWorklog(issueID string, worklog Worklog) (string, error) {
+
+	if c.apiVersion == ApiVersion3 {
+		return c.addWorklogV3(issueID, worklog)
+	}
+
+	return c.addWorklogV2(issueID, worklog)
+}
+
+func (c *Client) IssueURL(issueID, worklogID string) string {
+	return fmt.Sprintf("%v/browse/%v?focusedWorklogId=%s", c.host, issueID, worklogID)
+}
+
+func (c *Client) addWorklogV2(issueID string, worklog Worklog) (string, error) {
+
+	started := gojira.Time(worklog.Started)
+	worklogRecord := gojira.WorklogRecord{
+		Comment:          worklog.Comment,
+		TimeSpentSeconds: worklog.TimeSpentSeconds,
+		Started:          &started,
+	}
+
+	jwr, jr, err := c.client.Issue.AddWorklogRecord(issueID, &worklogRecord)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrJiraWorklogRecordAddFailed, gojira.NewJiraError(jr, err))
+	}
+
+	return jwr.ID, nil
+}
+
+func (c *Client) addWorklogV3(issueID string, worklog Worklog) (string, error) {
+
+	started := gojira.Time(worklog.Started)
+	worklogRecord := worklogRecordV3{
+		Comment:          ToADF(worklog.Comment, c.host),
+		TimeSpentSeconds: worklog.TimeSpentSeconds,
+		Started:          &started,
+	}
+
+	apiEndpoint := fmt.Sprintf("rest/api/3/issue/%s/worklog", issueID)
+	req, err := c.client.NewRequest("POST", apiEndpoint, &worklogRecord)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrJiraWorklogRecordAddFailed, err)
+	}
+
+	jwr := worklogRecordV3Response{}
+	jr, err := c.client.Do(req, &jwr)
+
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrJiraWorklogRecordAddFailed, gojira.NewJiraError(jr, err))
+	}
+
+	return jwr.ID, nil
+}
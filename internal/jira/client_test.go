@@ -0,0 +1,116 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+type recordedRequest struct {
+	path string
+	body map[string]any
+}
+
+func newFakeJiraServer(t *testing.T, status int, recorded *recordedRequest) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+
+		recorded.path = r.URL.Path
+		json.Unmarshal(data, &recorded.body)
+
+		w.WriteHeader(status)
+		w.Write([]byte(`{"id":"10001"}`))
+	}))
+}
+
+func TestNewClient(t *testing.T) {
+
+	t.Run("Use api v2 by default", func(t *testing.T) {
+		client, err := NewClient("https://acme.atlassian.net", "user", "password", 0)
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, client.apiVersion, ApiVersion2)
+	})
+
+	t.Run("Return error on unsupported api version", func(t *testing.T) {
+		_, err := NewClient("https://acme.atlassian.net", "user", "password", 4)
+
+		assert.Errors(t, err, ErrJiraUnsupportedApiVersion)
+	})
+}
+
+func TestAddWorklog(t *testing.T) {
+
+	worklog := Worklog{
+		Comment:          "Some comment",
+		Started:          time.Date(2025, time.January, 8, 10, 30, 0, 1000000, time.UTC),
+		TimeSpentSeconds: 900,
+	}
+
+	t.Run("Add worklog using api v2", func(t *testing.T) {
+		recorded := recordedRequest{}
+		server := newFakeJiraServer(t, http.StatusCreated, &recorded)
+		defer server.Close()
+
+		client, _ := NewClient(server.URL, "user", "password", ApiVersion2)
+		id, err := client.AddWorklog("ABC-12", worklog)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, id, "10001")
+		assert.Strings(t, recorded.path, "/rest/api/2/issue/ABC-12/worklog")
+		assert.Strings(t, recorded.body["comment"].(string), "Some comment")
+		assert.Strings(t, recorded.body["started"].(string), "2025-01-08T10:30:00.001+0000")
+	})
+
+	t.Run("Add worklog using api v3", func(t *testing.T) {
+		recorded := recordedRequest{}
+		server := newFakeJiraServer(t, http.StatusCreated, &recorded)
+		defer server.Close()
+
+		client, _ := NewClient(server.URL, "user", "password", ApiVersion3)
+		id, err := client.AddWorklog("ABC-12", worklog)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, id, "10001")
+		assert.Strings(t, recorded.path, "/rest/api/3/issue/ABC-12/worklog")
+		assert.Strings(t, recorded.body["comment"].(map[string]any)["type"].(string), "doc")
+		assert.Ints(t, int(recorded.body["timeSpentSeconds"].(float64)), 900)
+	})
+
+	t.Run("Return error on rejected worklog", func(t *testing.T) {
+		for _, apiVersion := range []int{ApiVersion2, ApiVersion3} {
+			recorded := recordedRequest{}
+			server := newFakeJiraServer(t, http.StatusBadRequest, &recorded)
+
+			client, _ := NewClient(server.URL, "user", "password", apiVersion)
+			_, err := client.AddWorklog("ABC-12", worklog)
+
+			assert.Bools(t, errors.Is(err, ErrJiraWorklogRecordAddFailed), true)
+
+			server.Close()
+		}
+	})
+}
+
+func TestIssueURL(t *testing.T) {
+	client, _ := NewClient("https://acme.atlassian.net/", "user", "password", ApiVersion3)
+
+	assert.Strings(t, client.IssueURL("ABC-12", "10001"), "https://acme.atlassian.net/browse/ABC-12?focusedWorklogId=10001")
+}
+
+func TestError(t *testing.T) {
+	t.Run("Return error message", func(t *testing.T) {
+		got := JiraErr("Error message").Error()
+		want := "Error message"
+
+		assert.Strings(t, got, want)
+	})
+}
@@ -0,0 +1,98 @@
+package outcome
+
+import (
+	"bytes"
+	"text/template"
+)
+
+const (
+	capsTemplate = `-------
+CAPS
+-------
+{{printf "%-20s %-16s %-10s %-10s %-10s %s" "SCOPE" "PERIOD" "TOTAL" "LOGGED" "CAP" "STATUS"}}
+{{- range .}}
+{{printf "%-20s %-16s %-10s %-10s %-10s %s" .Scope .Period .TotalTime .LoggedTime .Cap .Status}}
+{{- end}}
+---------
+`
+)
+
+// CapData is a cap of the period, total time includes time logged in previous runs
+type CapData struct {
+	Scope         string
+	Period        string
+	TotalSeconds  int
+	LoggedSeconds int
+	CapSeconds    int
+	Action        string
+}
+
+type Cap struct {
+	Scope      string
+	Period     string
+	TotalTime  string
+	LoggedTime string
+	Cap        string
+	Status     string
+}
+
+func GetCapsSummary(capsData []CapData) (string, error) {
+
+	caps := []Cap{}
+
+	for _, capData := range capsData {
+		capSummary, err := capData.prepareCap()
+
+		if err != nil {
+			return "", err
+		}
+
+		caps = append(caps, capSummary)
+	}
+
+	var output bytes.Buffer
+
+	t := template.Must(template.New("caps").Parse(capsTemplate))
+
+	t.Execute(&output, caps)
+
+	return output.String(), nil
+}
+
+func (c *CapData) prepareCap() (Cap, error) {
+
+	data := SummaryData{}
+
+	totalTime, err := data.getTotalTime(c.TotalSeconds)
+
+	if err != nil {
+		return Cap{}, err
+	}
+
+	loggedTime, err := data.getTotalTime(c.LoggedSeconds)
+
+	if err != nil {
+		return Cap{}, err
+	}
+
+	capTime, err := data.getTotalTime(c.CapSeconds)
+
+	if err != nil {
+		return Cap{}, err
+	}
+
+	status := "ok"
+
+	if c.TotalSeconds > c.CapSeconds {
+		status = "exceeded (" + c.Action + ")"
+	}
+
+	return Cap{
+		Scope:      c.Scope,
+		Period:     c.Period,
+		TotalTime:  totalTime,
+		LoggedTime: loggedTime,
+		Cap:        capTime,
+		Status:     status,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package outcome
+
+import (
+	"testing"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+func TestGetCapsSummary(t *testing.T) {
+
+	t.Run("Get templated caps summary", func(t *testing.T) {
+		capsData := []CapData{
+			{Scope: "ws_1/client_1", Period: "2025-01-08", TotalSeconds: 39600, LoggedSeconds: 7200, CapSeconds: 28800, Action: "scale"},
+			{Scope: "global", Period: "week 2025-01-06", TotalSeconds: 36000, CapSeconds: 144000, Action: "warn"},
+		}
+
+		got, err := GetCapsSummary(capsData)
+
+		want := `-------
+CAPS
+-------
+SCOPE                PERIOD           TOTAL      LOGGED     CAP        STATUS
+ws_1/client_1        2025-01-08       11h0m0s    2h0m0s     8h0m0s     exceeded (scale)
+global               week 2025-01-06  10h0m0s    0s         40h0m0s    ok
+---------
+`
+		assert.Errors(t, err, nil)
+		assert.Strings(t, got, want)
+	})
+
+	t.Run("Get errors on invalid input data", func(t *testing.T) {
+		_, err := GetCapsSummary([]CapData{{TotalSeconds: 10009283729293}})
+
+		assert.Errors(t, err, ErrSummaryParseTotalTimeDurationError)
+	})
+}
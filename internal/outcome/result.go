@@ -0,0 +1,143 @@
+package outcome
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// exit codes of the process, cron wrappers can tell them apart
+	ExitOk             = 0
+	ExitFailure        = 1
+	ExitConfigError    = 2
+	ExitPartialFailure = 3
+	ExitNothingToDo    = 4
+
+	ResultPlanned = "planned"
+	ResultLogged  = "logged"
+	ResultFailed  = "failed"
+	ResultSkipped = "skipped"
+	ResultBlocked = "blocked"
+
+	ErrResultFileWrite = OutcomeErr("Cannot write run result file")
+)
+
+// ResultCounts are numbers of worklogs by result, planned worklogs are counted in dry run only
+type ResultCounts struct {
+	Planned int `json:"planned"`
+	Logged  int `json:"logged"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	Blocked int `json:"blocked"`
+}
+
+type WorkspaceResult struct {
+	Error   string                   `json:"error,omitempty"`
+	Clients map[string]*ResultCounts `json:"clients"`
+}
+
+// RunResult is the machine readable result of a run
+type RunResult struct {
+	Command    string                      `json:"command"`
+	Apply      bool                        `json:"apply"`
+	StartedAt  time.Time                   `json:"started_at"`
+	ExitCode   int                         `json:"exit_code"`
+	Error      string                      `json:"error,omitempty"`
+	Total      ResultCounts                `json:"total"`
+	Workspaces map[string]*WorkspaceResult `json:"workspaces"`
+}
+
+func NewRunResult(command []string, apply bool, startedAt time.Time) *RunResult {
+	return &RunResult{
+		Command:    strings.Join(command, " "),
+		Apply:      apply,
+		StartedAt:  startedAt,
+		Workspaces: map[string]*WorkspaceResult{},
+	}
+}
+
+// Add counts worklog of workspace client with given result
+func (r *RunResult) Add(workspace, client, result string) {
+
+	workspaceResult := r.workspace(workspace)
+
+	if _, ok := workspaceResult.Clients[client]; !ok {
+		workspaceResult.Clients[client] = &ResultCounts{}
+	}
+
+	workspaceResult.Clients[client].add(result)
+	r.Total.add(result)
+}
+
+// WorkspaceFailed records workspace which couldn't be processed at all
+func (r *RunResult) WorkspaceFailed(workspace string, err error) {
+	r.workspace(workspace).Error = err.Error()
+}
+
+// GetExitCode tells whether anything was done and whether it failed completely or partially.
+// Failed workspaces count as failures, logged (or planned in dry run) worklogs as successes
+func (r *RunResult) GetExitCode() int {
+
+	failed := r.Total.Failed
+	succeeded := r.Total.Logged + r.Total.Planned
+
+	for _, workspaceResult := range r.Workspaces {
+		if workspaceResult.Error != "" {
+			failed++
+		}
+	}
+
+	switch {
+	case failed == 0 && succeeded == 0:
+		return ExitNothingToDo
+	case failed == 0:
+		return ExitOk
+	case succeeded == 0:
+		return ExitFailure
+	default:
+		return ExitPartialFailure
+	}
+}
+
+func (r *RunResult) Save(path string) error {
+
+	data, err := json.MarshalIndent(r, "", "  ")
+
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrResultFileWrite, err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("%w: %w", ErrResultFileWrite, err)
+	}
+
+	return nil
+}
+
+func (r *RunResult) workspace(workspace string) *WorkspaceResult {
+
+	if _, ok := r.Workspaces[workspace]; !ok {
+		r.Workspaces[workspace] = &WorkspaceResult{Clients: map[string]*ResultCounts{}}
+	}
+
+	return r.Workspaces[workspace]
+}
+
+func (c *ResultCounts) add(result string) {
+
+	switch result {
+	case ResultPlanned:
+		c.Planned++
+	case ResultLogged:
+		c.Logged++
+	case ResultFailed:
+		c.Failed++
+	case ResultSkipped:
+		c.Skipped++
+	case ResultBlocked:
+		c.Blocked++
+	}
+}
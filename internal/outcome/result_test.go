@@ -0,0 +1,101 @@
+package outcome
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+func TestRunResult(t *testing.T) {
+
+	startedAt := time.Date(2025, time.January, 13, 10, 0, 0, 0, time.UTC)
+
+	t.Run("Count worklogs per workspace and client", func(t *testing.T) {
+		result := NewRunResult([]string{"migrate"}, true, startedAt)
+
+		result.Add("ws_1", "acme", ResultLogged)
+		result.Add("ws_1", "acme", ResultLogged)
+		result.Add("ws_1", "acme", ResultSkipped)
+		result.Add("ws_1", "globex", ResultBlocked)
+		result.Add("ws_2", "acme", ResultFailed)
+
+		assert.Ints(t, result.Workspaces["ws_1"].Clients["acme"].Logged, 2)
+		assert.Ints(t, result.Workspaces["ws_1"].Clients["acme"].Skipped, 1)
+		assert.Ints(t, result.Workspaces["ws_1"].Clients["globex"].Blocked, 1)
+		assert.Ints(t, result.Workspaces["ws_2"].Clients["acme"].Failed, 1)
+		assert.Ints(t, result.Total.Logged, 2)
+		assert.Ints(t, result.Total.Failed, 1)
+	})
+
+	t.Run("Get exit code", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			results []string
+			failed  bool
+			want    int
+		}{
+			{name: "Nothing to do", results: []string{ResultSkipped, ResultBlocked}, want: ExitNothingToDo},
+			{name: "Everything logged", results: []string{ResultLogged, ResultSkipped}, want: ExitOk},
+			{name: "Everything planned in dry run", results: []string{ResultPlanned}, want: ExitOk},
+			{name: "Partial failure", results: []string{ResultLogged, ResultFailed}, want: ExitPartialFailure},
+			{name: "Partial failure of workspace", results: []string{ResultLogged}, failed: true, want: ExitPartialFailure},
+			{name: "Total failure", results: []string{ResultFailed, ResultSkipped}, want: ExitFailure},
+			{name: "Total failure of workspace", failed: true, want: ExitFailure},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				result := NewRunResult([]string{"migrate"}, true, startedAt)
+
+				for _, worklogResult := range tt.results {
+					result.Add("ws_1", "acme", worklogResult)
+				}
+
+				if tt.failed {
+					result.WorkspaceFailed("ws_2", errors.New("Cannot fetch timeentries"))
+				}
+
+				assert.Ints(t, result.GetExitCode(), tt.want)
+			})
+		}
+	})
+
+	t.Run("Save result as json", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "result.json")
+		result := NewRunResult([]string{"migrate"}, false, startedAt)
+
+		result.Add("ws_1", "acme", ResultPlanned)
+		result.WorkspaceFailed("ws_2", errors.New("Cannot fetch timeentries"))
+		result.ExitCode = result.GetExitCode()
+
+		assert.Errors(t, result.Save(path), nil)
+
+		data, _ := os.ReadFile(path)
+		saved := map[string]any{}
+		json.Unmarshal(data, &saved)
+
+		assert.Strings(t, saved["command"].(string), "migrate")
+		assert.Ints(t, int(saved["exit_code"].(float64)), ExitPartialFailure)
+		assert.Strings(t, saved["started_at"].(string), "2025-01-13T10:00:00Z")
+
+		workspaces := saved["workspaces"].(map[string]any)
+		acme := workspaces["ws_1"].(map[string]any)["clients"].(map[string]any)["acme"].(map[string]any)
+
+		assert.Ints(t, int(acme["planned"].(float64)), 1)
+		assert.Strings(t, workspaces["ws_2"].(map[string]any)["error"].(string), "Cannot fetch timeentries")
+	})
+
+	t.Run("Return error when file cannot be written", func(t *testing.T) {
+		result := NewRunResult([]string{"migrate"}, false, startedAt)
+
+		err := result.Save(filepath.Join(t.TempDir(), "missing", "result.json"))
+
+		assert.ErrorsIs(t, err, ErrResultFileWrite)
+		assert.Bools(t, errors.Is(err, os.ErrNotExist), true)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"html/template"
+	"slices"
 	"time"
 )
 
@@ -18,6 +19,19 @@ Time entries range: {{.Start}} - {{.End}}
 Number of time entries: {{.TimeEntriesNumber}}
 Total time: {{.TotalTime}}
 Total dosko: {{.TotalDoskoTime}} (t={{.Dosko}}m)
+{{- if .Strategies}}
+Rounding strategies:
+{{printf "%-10s %-12s %s" "STRATEGY" "TOTAL" "DIFF"}}
+{{- range .Strategies}}
+{{printf "%-10s %-12s %s" .Name .TotalTime .Difference}}
+{{- end}}
+{{- end}}
+{{- if .IgnoredTimeEntries}}
+Ignored time entries: {{len .IgnoredTimeEntries}} (total: {{.IgnoredTotalTime}})
+{{- range .IgnoredTimeEntries}}
+- {{.Description}} ({{.TimeSpent}})
+{{- end}}
+{{- end}}
 ---------
 `
 )
@@ -36,16 +50,37 @@ type SummaryData struct {
 	totalTime      int
 	totalDoskoTime int
 	doskoFactor    int
+	strategyTotals map[string]int
+	ignoredEntries []ignoredTimeEntry
+}
+
+type ignoredTimeEntry struct {
+	description string
+	timeSpent   int
+}
+
+type IgnoredTimeEntrySummary struct {
+	Description string
+	TimeSpent   string
+}
+
+type StrategySummary struct {
+	Name       string
+	TotalTime  string
+	Difference string
 }
 
 type Summary struct {
-	Workspace         string
-	Start             string
-	End               string
-	TimeEntriesNumber int
-	TotalTime         string
-	TotalDoskoTime    string
-	Dosko             int
+	Workspace          string
+	Start              string
+	End                string
+	TimeEntriesNumber  int
+	TotalTime          string
+	TotalDoskoTime     string
+	Dosko              int
+	Strategies         []StrategySummary
+	IgnoredTimeEntries []IgnoredTimeEntrySummary
+	IgnoredTotalTime   string
 }
 
 func (d *SummaryData) IncreaseTimeEntryCount() {
@@ -64,6 +99,21 @@ func (d *SummaryData) AddDoskoFactor(doskoFactor int) {
 	d.doskoFactor = doskoFactor
 }
 
+func (d *SummaryData) AddStrategyDurations(strategyDurations map[string]int) {
+
+	if d.strategyTotals == nil {
+		d.strategyTotals = map[string]int{}
+	}
+
+	for strategy, duration := range strategyDurations {
+		d.strategyTotals[strategy] += duration
+	}
+}
+
+func (d *SummaryData) AddIgnoredTimeEntry(description string, timeEntryDuration int) {
+	d.ignoredEntries = append(d.ignoredEntries, ignoredTimeEntry{description: description, timeSpent: timeEntryDuration})
+}
+
 func (d *SummaryData) getTotalTime(totalTime int) (string, error) {
 	parsedDuration, err := time.ParseDuration(fmt.Sprintf("%ds", totalTime))
 
@@ -112,15 +162,93 @@ func (d *SummaryData) prepareSummaryData() (Summary, error) {
 		return Summary{}, err
 	}
 
+	strategies, err := d.prepareStrategySummaries()
+
+	if err != nil {
+		return Summary{}, err
+	}
+
+	ignoredTimeEntries, ignoredTotalTime, err := d.prepareIgnoredTimeEntrySummaries()
+
+	if err != nil {
+		return Summary{}, err
+	}
+
 	summary := Summary{
-		Workspace:         d.Workspace,
-		Start:             d.Start.Format(timeFormat),
-		End:               d.End.Format(timeFormat),
-		TimeEntriesNumber: d.entriesCount,
-		TotalTime:         totalTime,
-		TotalDoskoTime:    totalDoskoTime,
-		Dosko:             d.doskoFactor,
+		Workspace:          d.Workspace,
+		Start:              d.Start.Format(timeFormat),
+		End:                d.End.Format(timeFormat),
+		TimeEntriesNumber:  d.entriesCount,
+		TotalTime:          totalTime,
+		TotalDoskoTime:     totalDoskoTime,
+		Dosko:              d.doskoFactor,
+		Strategies:         strategies,
+		IgnoredTimeEntries: ignoredTimeEntries,
+		IgnoredTotalTime:   ignoredTotalTime,
 	}
 
 	return summary, nil
 }
+
+func (d *SummaryData) prepareStrategySummaries() ([]StrategySummary, error) {
+
+	strategies := make([]string, 0, len(d.strategyTotals))
+
+	for strategy := range d.strategyTotals {
+		strategies = append(strategies, strategy)
+	}
+
+	slices.Sort(strategies)
+
+	summaries := []StrategySummary{}
+
+	for _, strategy := range strategies {
+		totalTime, err := d.getTotalTime(d.strategyTotals[strategy])
+
+		if err != nil {
+			return nil, err
+		}
+
+		difference, err := d.getTotalTime(d.strategyTotals[strategy] - d.totalTime)
+
+		if err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, StrategySummary{
+			Name:       strategy,
+			TotalTime:  totalTime,
+			Difference: difference,
+		})
+	}
+
+	return summaries, nil
+}
+
+func (d *SummaryData) prepareIgnoredTimeEntrySummaries() ([]IgnoredTimeEntrySummary, string, error) {
+
+	summaries := []IgnoredTimeEntrySummary{}
+	total := 0
+
+	for _, ignoredEntry := range d.ignoredEntries {
+		timeSpent, err := d.getTotalTime(ignoredEntry.timeSpent)
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		total += ignoredEntry.timeSpent
+		summaries = append(summaries, IgnoredTimeEntrySummary{
+			Description: ignoredEntry.description,
+			TimeSpent:   timeSpent,
+		})
+	}
+
+	totalTime, err := d.getTotalTime(total)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return summaries, totalTime, nil
+}
@@ -96,6 +96,74 @@ Total dosko: 3m20s (t=5m)
 		assert.Strings(t, got, want)
 	})
 
+	t.Run("Get templated summary with rounding strategies", func(t *testing.T) {
+
+		data := SummaryData{
+			Workspace:      "WorkspaceKey",
+			Start:          time.Date(2024, time.April, 11, 21, 34, 01, 0, time.UTC),
+			End:            time.Date(2024, time.May, 11, 21, 34, 01, 0, time.UTC),
+			entriesCount:   2,
+			totalTime:      1920,
+			totalDoskoTime: 1800,
+			doskoFactor:    15,
+		}
+
+		data.AddStrategyDurations(map[string]int{"nearest": 900, "ceil": 1800, "floor": 900})
+		data.AddStrategyDurations(map[string]int{"nearest": 900, "ceil": 900, "floor": 900})
+
+		got, _ := data.GetSummary()
+
+		want := `Workspace: WorkspaceKey
+-------
+SUMMARY
+-------
+Time entries range: 2024-04-11 21:34:01 - 2024-05-11 21:34:01
+Number of time entries: 2
+Total time: 32m0s
+Total dosko: 30m0s (t=15m)
+Rounding strategies:
+STRATEGY   TOTAL        DIFF
+ceil       45m0s        13m0s
+floor      30m0s        -2m0s
+nearest    30m0s        -2m0s
+---------
+`
+		assert.Strings(t, got, want)
+	})
+
+	t.Run("Get templated summary with ignored time entries", func(t *testing.T) {
+
+		data := SummaryData{
+			Workspace:      "WorkspaceKey",
+			Start:          time.Date(2024, time.April, 11, 21, 34, 01, 0, time.UTC),
+			End:            time.Date(2024, time.May, 11, 21, 34, 01, 0, time.UTC),
+			entriesCount:   1,
+			totalTime:      900,
+			totalDoskoTime: 900,
+			doskoFactor:    15,
+		}
+
+		data.AddIgnoredTimeEntry("ABC-1 accidental timer", 5)
+		data.AddIgnoredTimeEntry("ABC-2 another one", 40)
+
+		got, _ := data.GetSummary()
+
+		want := `Workspace: WorkspaceKey
+-------
+SUMMARY
+-------
+Time entries range: 2024-04-11 21:34:01 - 2024-05-11 21:34:01
+Number of time entries: 1
+Total time: 15m0s
+Total dosko: 15m0s (t=15m)
+Ignored time entries: 2 (total: 45s)
+- ABC-1 accidental timer (5s)
+- ABC-2 another one (40s)
+---------
+`
+		assert.Strings(t, got, want)
+	})
+
 	t.Run("Get errors on invalid totalTime input data", func(t *testing.T) {
 		data := SummaryData{
 			totalTime: 10009283729293,
@@ -25,9 +25,15 @@ type DoskoDetails struct {
 	OriginalTime string
 	RoundedTime  string
 	Precision    int
+	Strategy     string
 }
 
 func (dd *DoskoDetails) toString() string {
+
+	if dd.Strategy != "" {
+		return fmt.Sprintf("%+v (clockify: %+v stachurskyMode: %+vm strategy: %v)", dd.RoundedTime, dd.OriginalTime, dd.Precision, dd.Strategy)
+	}
+
 	return fmt.Sprintf("%+v (clockify: %+v stachurskyMode: %+vm)", dd.RoundedTime, dd.OriginalTime, dd.Precision)
 }
 
@@ -35,6 +41,7 @@ type WorklogData struct {
 	Description string
 	Workspace   string
 	Client      string
+	ClientRule  string
 	Project     string
 	Date        time.Time
 	TimeSpent   DoskoDetails
@@ -70,7 +77,7 @@ func (w *WorklogData) prepareWorklogData() Worklog {
 	return Worklog{
 		Description: w.Description,
 		Workspace:   w.Workspace,
-		Client:      w.Client,
+		Client:      w.clientToString(),
 		Project:     w.Project,
 		Date:        w.Date.Format(timeFormat),
 		TimeSpent:   w.TimeSpent.toString(),
@@ -78,3 +85,12 @@ func (w *WorklogData) prepareWorklogData() Worklog {
 		Tags:        w.Tags,
 	}
 }
+
+func (w *WorklogData) clientToString() string {
+
+	if w.ClientRule != "" {
+		return fmt.Sprintf("%v (matched by %v)", w.Client, w.ClientRule)
+	}
+
+	return w.Client
+}
@@ -41,3 +41,31 @@ Tags: [Tag1 Tag2]
 `
 	assert.Strings(t, got, want)
 }
+
+func TestGetWorklogWithRoundingStrategy(t *testing.T) {
+
+	data := WorklogData{
+		TimeSpent: DoskoDetails{
+			OriginalTime: "8h7m0s",
+			RoundedTime:  "8h15m0s",
+			Precision:    15,
+			Strategy:     "ceil",
+		},
+	}
+
+	got := data.prepareWorklogData()
+
+	assert.Strings(t, got.TimeSpent, "8h15m0s (clockify: 8h7m0s stachurskyMode: 15m strategy: ceil)")
+}
+
+func TestGetWorklogWithClientRule(t *testing.T) {
+
+	data := WorklogData{
+		Client:     "lodz",
+		ClientRule: "alias",
+	}
+
+	got := data.prepareWorklogData()
+
+	assert.Strings(t, got.Client, "lodz (matched by alias)")
+}
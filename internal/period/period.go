@@ -0,0 +1,141 @@
+package period
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	ErrPeriodInvalidDate      = PeriodErr("Cannot parse date - use YYYY-MM-DD, YYYY-MM-DDTHH:MM, today, yesterday, this-week, last-week, this-month, last-month or weekday name")
+	ErrPeriodInvalidRange     = PeriodErr("Start of the date range has to be before its end")
+	ErrPeriodInvalidWeekStart = PeriodErr("Week start has to be a weekday name, e.g. monday")
+
+	dayFormat    = "2006-01-02"
+	minuteFormat = "2006-01-02T15:04"
+)
+
+type PeriodErr string
+
+func (e PeriodErr) Error() string {
+	return string(e)
+}
+
+// Calendar aligns dates to calendar days and weeks of given location
+type Calendar struct {
+	Location  *time.Location
+	WeekStart time.Weekday
+}
+
+// LastDays returns range from the beginning of the day given number of days ago until now
+func (c Calendar) LastDays(days int, now time.Time) (time.Time, time.Time) {
+	return c.StartOfDay(now.AddDate(0, 0, -days)), now
+}
+
+// Range returns start and end of given date expression, end is exclusive.
+// Date with time (YYYY-MM-DDTHH:MM) is a single moment, so its start equals end.
+func (c Calendar) Range(expression string, now time.Time) (time.Time, time.Time, error) {
+
+	now = now.In(c.Location)
+	today := c.StartOfDay(now)
+	expression = strings.TrimSpace(expression)
+
+	switch strings.ToLower(expression) {
+	case "now":
+		return now, now, nil
+	case "today":
+		return today, today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), today, nil
+	case "this-week":
+		weekStart := c.startOfWeek(today)
+		return weekStart, weekStart.AddDate(0, 0, 7), nil
+	case "last-week":
+		weekStart := c.startOfWeek(today)
+		return weekStart.AddDate(0, 0, -7), weekStart, nil
+	case "this-month":
+		monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, c.Location)
+		return monthStart, monthStart.AddDate(0, 1, 0), nil
+	case "last-month":
+		monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, c.Location)
+		return monthStart.AddDate(0, -1, 0), monthStart, nil
+	}
+
+	if weekday, err := ParseWeekday(expression); err == nil {
+		// the most recent given weekday, today included
+		day := today.AddDate(0, 0, -((int(today.Weekday()) - int(weekday) + 7) % 7))
+		return day, day.AddDate(0, 0, 1), nil
+	}
+
+	if day, err := time.ParseInLocation(dayFormat, expression, c.Location); err == nil {
+		return day, day.AddDate(0, 0, 1), nil
+	}
+
+	for _, format := range []string{minuteFormat, "2006-01-02 15:04"} {
+		if moment, err := time.ParseInLocation(format, expression, c.Location); err == nil {
+			return moment, moment, nil
+		}
+	}
+
+	return time.Time{}, time.Time{}, ErrPeriodInvalidDate
+}
+
+// Between returns range from the start of "from" expression to the end of "to" expression
+func (c Calendar) Between(from, to string, now time.Time) (time.Time, time.Time, error) {
+
+	start, _, err := c.Range(from, now)
+
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	_, end, err := c.Range(to, now)
+
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, ErrPeriodInvalidRange
+	}
+
+	return start, end, nil
+}
+
+// ParseWeekday parses english weekday name, e.g. monday
+func ParseWeekday(name string) (time.Weekday, error) {
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), name) {
+			return weekday, nil
+		}
+	}
+
+	return time.Sunday, ErrPeriodInvalidWeekStart
+}
+
+// Day returns calendar day of the moment, e.g. 2025-01-06
+func (c Calendar) Day(moment time.Time) string {
+	return moment.In(c.Location).Format(dayFormat)
+}
+
+// Week returns calendar week of the moment named after its first day, e.g. week 2025-01-06
+func (c Calendar) Week(moment time.Time) string {
+	return "week " + c.StartOfWeek(moment).Format(dayFormat)
+}
+
+// StartOfWeek returns the beginning of the first day of the week of the moment
+func (c Calendar) StartOfWeek(moment time.Time) time.Time {
+	return c.startOfWeek(c.StartOfDay(moment))
+}
+
+// StartOfDay returns the beginning of the day of the moment
+func (c Calendar) StartOfDay(moment time.Time) time.Time {
+
+	moment = moment.In(c.Location)
+
+	return time.Date(moment.Year(), moment.Month(), moment.Day(), 0, 0, 0, 0, c.Location)
+}
+
+func (c Calendar) startOfWeek(day time.Time) time.Time {
+	return day.AddDate(0, 0, -((int(day.Weekday()) - int(c.WeekStart) + 7) % 7))
+}
@@ -0,0 +1,137 @@
+package period
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+const (
+	testFormat = "2006-01-02 15:04"
+)
+
+func TestRange(t *testing.T) {
+
+	location, _ := time.LoadLocation("Europe/Warsaw")
+	calendar := Calendar{Location: location, WeekStart: time.Monday}
+	// wednesday, late evening in UTC is already thursday in Warsaw
+	now := time.Date(2025, time.January, 15, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expression string
+		wantStart  string
+		wantEnd    string
+	}{
+		{"today", "2025-01-16 00:00", "2025-01-17 00:00"},
+		{"yesterday", "2025-01-15 00:00", "2025-01-16 00:00"},
+		{"this-week", "2025-01-13 00:00", "2025-01-20 00:00"},
+		{"last-week", "2025-01-06 00:00", "2025-01-13 00:00"},
+		{"this-month", "2025-01-01 00:00", "2025-02-01 00:00"},
+		{"last-month", "2024-12-01 00:00", "2025-01-01 00:00"},
+		{"monday", "2025-01-13 00:00", "2025-01-14 00:00"},
+		{"Thursday", "2025-01-16 00:00", "2025-01-17 00:00"},
+		{"friday", "2025-01-10 00:00", "2025-01-11 00:00"},
+		{"2025-01-01", "2025-01-01 00:00", "2025-01-02 00:00"},
+		{"2025-01-01T08:30", "2025-01-01 08:30", "2025-01-01 08:30"},
+		{"now", "2025-01-16 00:30", "2025-01-16 00:30"},
+	}
+
+	for _, tt := range tests {
+		t.Run("Parse "+tt.expression, func(t *testing.T) {
+			start, end, err := calendar.Range(tt.expression, now)
+
+			assert.Errors(t, err, nil)
+			assert.Strings(t, start.Format(testFormat), tt.wantStart)
+			assert.Strings(t, end.Format(testFormat), tt.wantEnd)
+		})
+	}
+
+	t.Run("Start week on sunday", func(t *testing.T) {
+		sundayCalendar := Calendar{Location: location, WeekStart: time.Sunday}
+
+		start, end, err := sundayCalendar.Range("last-week", now)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, start.Format(testFormat), "2025-01-05 00:00")
+		assert.Strings(t, end.Format(testFormat), "2025-01-12 00:00")
+	})
+
+	t.Run("Throw error on unknown expression", func(t *testing.T) {
+		_, _, err := calendar.Range("last-year", now)
+
+		assert.Errors(t, err, ErrPeriodInvalidDate)
+	})
+}
+
+func TestBetween(t *testing.T) {
+
+	calendar := Calendar{Location: time.UTC, WeekStart: time.Monday}
+	now := time.Date(2025, time.February, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Include whole end day", func(t *testing.T) {
+		start, end, err := calendar.Between("2025-01-01", "2025-01-31", now)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, start.Format(testFormat), "2025-01-01 00:00")
+		assert.Strings(t, end.Format(testFormat), "2025-02-01 00:00")
+	})
+
+	t.Run("Use single keyword as from and to", func(t *testing.T) {
+		start, end, err := calendar.Between("last-month", "last-month", now)
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, start.Format(testFormat), "2025-01-01 00:00")
+		assert.Strings(t, end.Format(testFormat), "2025-02-01 00:00")
+	})
+
+	t.Run("Throw error on reversed range", func(t *testing.T) {
+		_, _, err := calendar.Between("today", "last-week", now)
+
+		assert.Errors(t, err, ErrPeriodInvalidRange)
+	})
+}
+
+func TestLastDays(t *testing.T) {
+
+	calendar := Calendar{Location: time.UTC, WeekStart: time.Monday}
+	now := time.Date(2025, time.January, 15, 9, 45, 0, 0, time.UTC)
+
+	start, end := calendar.LastDays(2, now)
+
+	assert.Strings(t, start.Format(testFormat), "2025-01-13 00:00")
+	assert.Strings(t, end.Format(testFormat), "2025-01-15 09:45")
+}
+
+func TestDayAndWeek(t *testing.T) {
+
+	location, _ := time.LoadLocation("Europe/Warsaw")
+	// sunday, late evening in UTC is already monday in Warsaw
+	moment := time.Date(2025, time.January, 12, 23, 30, 0, 0, time.UTC)
+
+	t.Run("Use day and week of the calendar timezone", func(t *testing.T) {
+		calendar := Calendar{Location: location, WeekStart: time.Monday}
+
+		assert.Strings(t, calendar.Day(moment), "2025-01-13")
+		assert.Strings(t, calendar.Week(moment), "week 2025-01-13")
+		assert.Strings(t, calendar.StartOfWeek(moment).Format(testFormat), "2025-01-13 00:00")
+	})
+
+	t.Run("Start week on sunday", func(t *testing.T) {
+		calendar := Calendar{Location: location, WeekStart: time.Sunday}
+
+		assert.Strings(t, calendar.Week(moment), "week 2025-01-12")
+	})
+}
+
+func TestParseWeekday(t *testing.T) {
+
+	weekday, err := ParseWeekday("Sunday")
+
+	assert.Errors(t, err, nil)
+	assert.Ints(t, int(weekday), int(time.Sunday))
+
+	_, err = ParseWeekday("weekend")
+
+	assert.Errors(t, err, ErrPeriodInvalidWeekStart)
+}
@@ -0,0 +1,169 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"text/template"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/period"
+)
+
+const (
+	driftTemplate = `-------
+ROUNDING DRIFT
+-------
+Time entries range: {{.Start}} - {{.End}}
+Rounded time is recomputed with current settings - worklogs logged with older settings may differ.
+Time entries ignored by min_duration count as actual time with nothing rounded.
+{{- range .Sections}}
+
+{{printf "%-40s %-12s %-12s %-12s %s" .Name "ACTUAL" "ROUNDED" "DRIFT" "DRIFT %"}}
+{{- range .Rows}}
+{{printf "%-40s %-12s %-12s %-12s %s" .Name .ActualTime .RoundedTime .Drift .DriftPercent}}
+{{- end}}
+{{- end}}
+---------
+`
+	timeFormat = "2006-01-02 15:04:05"
+)
+
+type driftTotals struct {
+	actualSeconds  int
+	roundedSeconds int
+}
+
+type DriftData struct {
+	Start     time.Time
+	End       time.Time
+	byClient  map[string]*driftTotals
+	byProject map[string]*driftTotals
+	byWeek    map[string]*driftTotals
+	total     driftTotals
+	calendar  period.Calendar
+}
+
+type DriftRow struct {
+	Name         string
+	ActualTime   string
+	RoundedTime  string
+	Drift        string
+	DriftPercent string
+}
+
+type DriftSection struct {
+	Name string
+	Rows []DriftRow
+}
+
+type Drift struct {
+	Start    string
+	End      string
+	Sections []DriftSection
+}
+
+func NewDriftData(start, end time.Time, calendar period.Calendar) *DriftData {
+	return &DriftData{
+		Start:     start,
+		End:       end,
+		byClient:  map[string]*driftTotals{},
+		byProject: map[string]*driftTotals{},
+		byWeek:    map[string]*driftTotals{},
+		calendar:  calendar,
+	}
+}
+
+func (d *DriftData) Add(client, project string, started time.Time, actualSeconds, roundedSeconds int) {
+
+	for _, group := range []struct {
+		totals map[string]*driftTotals
+		key    string
+	}{
+		{d.byClient, client},
+		{d.byProject, client + "/" + project},
+		{d.byWeek, d.calendar.Week(started)},
+	} {
+		if _, ok := group.totals[group.key]; !ok {
+			group.totals[group.key] = &driftTotals{}
+		}
+
+		group.totals[group.key].add(actualSeconds, roundedSeconds)
+	}
+
+	d.total.add(actualSeconds, roundedSeconds)
+}
+
+func (d *DriftData) GetReport() string {
+
+	drift := Drift{
+		Start: d.Start.Format(timeFormat),
+		End:   d.End.Format(timeFormat),
+		Sections: []DriftSection{
+			{Name: "CLIENT", Rows: prepareDriftRows(d.byClient)},
+			{Name: "CLIENT/PROJECT", Rows: prepareDriftRows(d.byProject)},
+			{Name: "WEEK", Rows: prepareDriftRows(d.byWeek)},
+			{Name: "TOTAL", Rows: []DriftRow{d.total.prepareDriftRow("total")}},
+		},
+	}
+
+	var output bytes.Buffer
+
+	t := template.Must(template.New("drift").Parse(driftTemplate))
+
+	t.Execute(&output, drift)
+
+	return output.String()
+}
+
+func (t *driftTotals) add(actualSeconds, roundedSeconds int) {
+	t.actualSeconds += actualSeconds
+	t.roundedSeconds += roundedSeconds
+}
+
+func (t *driftTotals) prepareDriftRow(name string) DriftRow {
+
+	drift := t.roundedSeconds - t.actualSeconds
+	driftPercent := "-"
+
+	if t.actualSeconds != 0 {
+		driftPercent = fmt.Sprintf("%+.1f%%", float64(drift)/float64(t.actualSeconds)*100)
+	}
+
+	formattedDrift := formatSeconds(drift)
+
+	if drift > 0 {
+		formattedDrift = "+" + formattedDrift
+	}
+
+	return DriftRow{
+		Name:         name,
+		ActualTime:   formatSeconds(t.actualSeconds),
+		RoundedTime:  formatSeconds(t.roundedSeconds),
+		Drift:        formattedDrift,
+		DriftPercent: driftPercent,
+	}
+}
+
+func prepareDriftRows(totals map[string]*driftTotals) []DriftRow {
+
+	names := make([]string, 0, len(totals))
+
+	for name := range totals {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	rows := []DriftRow{}
+
+	for _, name := range names {
+		rows = append(rows, totals[name].prepareDriftRow(name))
+	}
+
+	return rows
+}
+
+func formatSeconds(seconds int) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
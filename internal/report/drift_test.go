@@ -0,0 +1,59 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+	"github.com/kruc/clockify-to-jira/internal/period"
+)
+
+func TestDriftReport(t *testing.T) {
+
+	t.Run("Get templated drift report", func(t *testing.T) {
+		start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.Local)
+		end := time.Date(2025, time.January, 14, 0, 0, 0, 0, time.Local)
+
+		data := NewDriftData(start, end, period.Calendar{Location: time.Local, WeekStart: time.Monday})
+		data.Add("acme", "web", time.Date(2025, time.January, 6, 10, 0, 0, 0, time.Local), 3000, 3600)
+		data.Add("acme", "api", time.Date(2025, time.January, 7, 10, 0, 0, 0, time.Local), 1000, 900)
+		data.Add("globex", "app", time.Date(2025, time.January, 13, 10, 0, 0, 0, time.Local), 1800, 1800)
+
+		got := data.GetReport()
+
+		want := `-------
+ROUNDING DRIFT
+-------
+Time entries range: 2025-01-01 00:00:00 - 2025-01-14 00:00:00
+Rounded time is recomputed with current settings - worklogs logged with older settings may differ.
+Time entries ignored by min_duration count as actual time with nothing rounded.
+
+CLIENT                                   ACTUAL       ROUNDED      DRIFT        DRIFT %
+acme                                     1h6m40s      1h15m0s      +8m20s       +12.5%
+globex                                   30m0s        30m0s        0s           +0.0%
+
+CLIENT/PROJECT                           ACTUAL       ROUNDED      DRIFT        DRIFT %
+acme/api                                 16m40s       15m0s        -1m40s       -10.0%
+acme/web                                 50m0s        1h0m0s       +10m0s       +20.0%
+globex/app                               30m0s        30m0s        0s           +0.0%
+
+WEEK                                     ACTUAL       ROUNDED      DRIFT        DRIFT %
+week 2025-01-06                          1h6m40s      1h15m0s      +8m20s       +12.5%
+week 2025-01-13                          30m0s        30m0s        0s           +0.0%
+
+TOTAL                                    ACTUAL       ROUNDED      DRIFT        DRIFT %
+total                                    1h36m40s     1h45m0s      +8m20s       +8.6%
+---------
+`
+		assert.Strings(t, got, want)
+	})
+
+	t.Run("Get drift report without time entries", func(t *testing.T) {
+		data := NewDriftData(time.Time{}, time.Time{}, period.Calendar{Location: time.Local, WeekStart: time.Monday})
+
+		row := data.total.prepareDriftRow("total")
+
+		assert.Strings(t, row.DriftPercent, "-")
+		assert.Strings(t, row.Drift, "0s")
+	})
+}
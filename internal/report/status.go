@@ -0,0 +1,129 @@
+package report
+
+import (
+	"bytes"
+	"slices"
+	"text/template"
+	"time"
+)
+
+const (
+	StatusPending = "pending"
+	StatusFailed  = "failed"
+	StatusLogged  = "logged"
+	StatusSkipped = "skipped"
+	StatusRunning = "running"
+
+	statusTemplate = `-------
+STATUS
+-------
+Time entries range: {{.Start}} - {{.End}}
+
+{{printf "%-40s %-9s %-9s %-9s %-9s %-9s %s" "WORKSPACE/CLIENT" "PENDING" "FAILED" "LOGGED" "SKIPPED" "RUNNING" "PENDING TIME"}}
+{{- range .Rows}}
+{{printf "%-40s %-9d %-9d %-9d %-9d %-9d %s" .Name .Pending .Failed .Logged .Skipped .Running .PendingTime}}
+{{- end}}
+---------
+`
+)
+
+type statusTotals struct {
+	counts         map[string]int
+	pendingSeconds int
+}
+
+type StatusData struct {
+	Start    time.Time
+	End      time.Time
+	byClient map[string]*statusTotals
+	total    statusTotals
+}
+
+type StatusRow struct {
+	Name        string
+	Pending     int
+	Failed      int
+	Logged      int
+	Skipped     int
+	Running     int
+	PendingTime string
+}
+
+type Status struct {
+	Start string
+	End   string
+	Rows  []StatusRow
+}
+
+func NewStatusData(start, end time.Time) *StatusData {
+	return &StatusData{
+		Start:    start,
+		End:      end,
+		byClient: map[string]*statusTotals{},
+		total:    statusTotals{counts: map[string]int{}},
+	}
+}
+
+// Add counts time entry of workspace client in given status, only pending time is summed
+func (d *StatusData) Add(workspace, client, status string, seconds int) {
+
+	key := workspace + "/" + client
+
+	if _, ok := d.byClient[key]; !ok {
+		d.byClient[key] = &statusTotals{counts: map[string]int{}}
+	}
+
+	d.byClient[key].add(status, seconds)
+	d.total.add(status, seconds)
+}
+
+func (d *StatusData) GetReport() string {
+
+	names := make([]string, 0, len(d.byClient))
+
+	for name := range d.byClient {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	status := Status{
+		Start: d.Start.Format(timeFormat),
+		End:   d.End.Format(timeFormat),
+		Rows:  []StatusRow{},
+	}
+
+	for _, name := range names {
+		status.Rows = append(status.Rows, d.byClient[name].prepareStatusRow(name))
+	}
+
+	status.Rows = append(status.Rows, d.total.prepareStatusRow("total"))
+
+	var output bytes.Buffer
+
+	t := template.Must(template.New("status").Parse(statusTemplate))
+
+	t.Execute(&output, status)
+
+	return output.String()
+}
+
+func (t *statusTotals) add(status string, seconds int) {
+	t.counts[status]++
+
+	if status == StatusPending {
+		t.pendingSeconds += seconds
+	}
+}
+
+func (t *statusTotals) prepareStatusRow(name string) StatusRow {
+	return StatusRow{
+		Name:        name,
+		Pending:     t.counts[StatusPending],
+		Failed:      t.counts[StatusFailed],
+		Logged:      t.counts[StatusLogged],
+		Skipped:     t.counts[StatusSkipped],
+		Running:     t.counts[StatusRunning],
+		PendingTime: formatSeconds(t.pendingSeconds),
+	}
+}
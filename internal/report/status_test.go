@@ -0,0 +1,41 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+func TestStatusReport(t *testing.T) {
+
+	t.Run("Get templated status report", func(t *testing.T) {
+		start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.Local)
+		end := time.Date(2025, time.January, 8, 0, 0, 0, 0, time.Local)
+
+		data := NewStatusData(start, end)
+		data.Add("ws_1", "acme", StatusPending, 1800)
+		data.Add("ws_1", "acme", StatusPending, 900)
+		data.Add("ws_1", "acme", StatusLogged, 3600)
+		data.Add("ws_1", "globex", StatusFailed, 600)
+		data.Add("ws_2", "acme", StatusSkipped, 60)
+		data.Add("ws_2", "acme", StatusRunning, 0)
+
+		got := data.GetReport()
+
+		want := `-------
+STATUS
+-------
+Time entries range: 2025-01-01 00:00:00 - 2025-01-08 00:00:00
+
+WORKSPACE/CLIENT                         PENDING   FAILED    LOGGED    SKIPPED   RUNNING   PENDING TIME
+ws_1/acme                                2         0         1         0         0         45m0s
+ws_1/globex                              0         1         0         0         0         0s
+ws_2/acme                                0         0         0         1         1         0s
+total                                    2         1         1         1         1         45m0s
+---------
+`
+
+		assert.Strings(t, got, want)
+	})
+}
@@ -0,0 +1,250 @@
+package review
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/worklog"
+)
+
+const (
+	ActionAccept     = "accept"
+	ActionSkip       = "skip"
+	ActionSkipAndTag = "skip-and-tag"
+
+	ErrReviewInputClosed = ReviewErr("Review input closed - nothing was applied")
+
+	dateFormat = "2006-01-02 15:04"
+)
+
+var (
+	issueIDPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]+-[0-9]+$`)
+)
+
+type ReviewErr string
+
+func (e ReviewErr) Error() string {
+	return string(e)
+}
+
+type Review struct {
+	input    *bufio.Scanner
+	output   io.Writer
+	location *time.Location
+}
+
+// New creates review displaying worklog dates in given location
+func New(input io.Reader, output io.Writer, location *time.Location) *Review {
+	return &Review{
+		input:    bufio.NewScanner(input),
+		output:   output,
+		location: location,
+	}
+}
+
+// Run asks for action of every worklog, edited issue, comment and duration are written to worklogs.
+// Worklogs blocked by time cap are accepted without asking - they are never applied
+func (r *Review) Run(worklogs []worklog.Worklog) ([]string, error) {
+
+	actions := make([]string, len(worklogs))
+
+	for index := range worklogs {
+		plannedWorklog := &worklogs[index]
+
+		if plannedWorklog.Blocked {
+			fmt.Fprintf(r.output, "\n%s blocked by time cap - it will be migrated on next run\n", plannedWorklog.IssueID)
+			actions[index] = ActionAccept
+			continue
+		}
+
+		action, err := r.reviewWorklog(plannedWorklog, index+1, len(worklogs))
+
+		if err != nil {
+			return nil, err
+		}
+
+		actions[index] = action
+	}
+
+	return actions, nil
+}
+
+// Confirm displays totals of accepted worklogs and asks whether to apply them
+func (r *Review) Confirm(worklogs []worklog.Worklog, actions []string) (bool, error) {
+
+	accepted, tagged, roundedSeconds, originalSeconds := 0, 0, 0, 0
+
+	for index, plannedWorklog := range worklogs {
+		switch {
+		case actions[index] == ActionAccept && !plannedWorklog.Blocked:
+			accepted++
+			roundedSeconds += plannedWorklog.RoundedSeconds
+			originalSeconds += plannedWorklog.TimeSpentSeconds
+		case actions[index] == ActionSkipAndTag:
+			tagged += len(plannedWorklog.TimeEntries)
+		}
+	}
+
+	if accepted == 0 && tagged == 0 {
+		fmt.Fprintln(r.output, "\nNothing to apply")
+
+		return false, nil
+	}
+
+	fmt.Fprintf(r.output, "\nApply %d worklogs - total %s (clockify: %s), tag %d time entries as skipped, skip %d worklogs? [y/N]: ",
+		accepted, formatSeconds(roundedSeconds), formatSeconds(originalSeconds), tagged, countActions(actions, ActionSkip))
+
+	answer, err := r.read()
+
+	if err != nil {
+		return false, err
+	}
+
+	answer = strings.ToLower(answer)
+
+	return answer == "y" || answer == "yes", nil
+}
+
+func (r *Review) reviewWorklog(plannedWorklog *worklog.Worklog, number, count int) (string, error) {
+
+	for {
+		fmt.Fprintf(r.output, "\nWorklog %d/%d\nIssue: %s\nClient: %s/%s\nDate: %s\nTime spent: %s (clockify: %s)\nComment: %s\n",
+			number, count,
+			plannedWorklog.IssueID,
+			plannedWorklog.Workspace, plannedWorklog.ClientID,
+			plannedWorklog.Started.In(r.location).Format(dateFormat),
+			formatSeconds(plannedWorklog.RoundedSeconds), formatSeconds(plannedWorklog.TimeSpentSeconds),
+			plannedWorklog.Comment,
+		)
+		fmt.Fprint(r.output, "[a]ccept, [s]kip, skip and [t]ag, edit [i]ssue, edit [c]omment, change [d]uration [a]: ")
+
+		answer, err := r.read()
+
+		if err != nil {
+			return "", err
+		}
+
+		switch strings.ToLower(answer) {
+		case "", "a", "accept":
+			return ActionAccept, nil
+		case "s", "skip":
+			return ActionSkip, nil
+		case "t", "tag":
+			return ActionSkipAndTag, nil
+		case "i", "issue":
+			err = r.editIssueID(plannedWorklog)
+		case "c", "comment":
+			err = r.editComment(plannedWorklog)
+		case "d", "duration":
+			err = r.editDuration(plannedWorklog)
+		default:
+			fmt.Fprintf(r.output, "Unknown answer %q - use a, s, t, i, c or d\n", answer)
+		}
+
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+func (r *Review) editIssueID(plannedWorklog *worklog.Worklog) error {
+
+	for {
+		fmt.Fprintf(r.output, "Issue [%s]: ", plannedWorklog.IssueID)
+
+		answer, err := r.read()
+
+		if err != nil || answer == "" {
+			return err
+		}
+
+		issueID := strings.ToUpper(answer)
+
+		if issueIDPattern.MatchString(issueID) {
+			plannedWorklog.IssueID = issueID
+
+			return nil
+		}
+
+		fmt.Fprintf(r.output, "Invalid issue key %q, e.g. ABC-12\n", answer)
+	}
+}
+
+func (r *Review) editComment(plannedWorklog *worklog.Worklog) error {
+
+	fmt.Fprint(r.output, "Comment (empty keeps current): ")
+
+	answer, err := r.read()
+
+	if err != nil || answer == "" {
+		return err
+	}
+
+	plannedWorklog.Comment = answer
+
+	return nil
+}
+
+// editDuration accepts go durations (1h30m) or minutes
+func (r *Review) editDuration(plannedWorklog *worklog.Worklog) error {
+
+	for {
+		fmt.Fprintf(r.output, "Duration - e.g. 1h30m or minutes [%s]: ", formatSeconds(plannedWorklog.RoundedSeconds))
+
+		answer, err := r.read()
+
+		if err != nil || answer == "" {
+			return err
+		}
+
+		duration, err := parseDuration(answer)
+
+		if err == nil && duration >= time.Minute {
+			plannedWorklog.RoundedSeconds = int(duration.Seconds())
+
+			return nil
+		}
+
+		fmt.Fprintf(r.output, "Invalid duration %q, minimum is 1m\n", answer)
+	}
+}
+
+func (r *Review) read() (string, error) {
+
+	if !r.input.Scan() {
+		return "", ErrReviewInputClosed
+	}
+
+	return strings.TrimSpace(r.input.Text()), nil
+}
+
+func parseDuration(value string) (time.Duration, error) {
+
+	if minutes, err := strconv.Atoi(value); err == nil {
+		return time.Duration(minutes) * time.Minute, nil
+	}
+
+	return time.ParseDuration(value)
+}
+
+func countActions(actions []string, action string) int {
+
+	count := 0
+
+	for _, value := range actions {
+		if value == action {
+			count++
+		}
+	}
+
+	return count
+}
+
+func formatSeconds(seconds int) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
@@ -0,0 +1,123 @@
+package review
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+	"github.com/kruc/clockify-to-jira/internal/clockify"
+	"github.com/kruc/clockify-to-jira/internal/worklog"
+)
+
+func newWorklogs() []worklog.Worklog {
+
+	started := time.Date(2025, time.January, 13, 10, 0, 0, 0, time.UTC)
+
+	return []worklog.Worklog{
+		{Workspace: "ws_1", ClientID: "acme", IssueID: "ABC-1", Comment: "fix login", Started: started, TimeSpentSeconds: 1620, RoundedSeconds: 1800, TimeEntries: []clockify.TimeEntry{{ID: "id1"}}},
+		{Workspace: "ws_1", ClientID: "acme", IssueID: "ABC-2", Comment: "review", Started: started, TimeSpentSeconds: 600, RoundedSeconds: 900, TimeEntries: []clockify.TimeEntry{{ID: "id2"}}},
+		{Workspace: "ws_1", ClientID: "acme", IssueID: "ABC-3", Comment: "meeting", Started: started, TimeSpentSeconds: 3600, RoundedSeconds: 3600, TimeEntries: []clockify.TimeEntry{{ID: "id3"}, {ID: "id4"}}},
+		{Workspace: "ws_1", ClientID: "acme", IssueID: "ABC-4", Comment: "blocked", Started: started, TimeSpentSeconds: 600, RoundedSeconds: 900, Blocked: true},
+	}
+}
+
+func TestRun(t *testing.T) {
+
+	t.Run("Ask for action of every worklog", func(t *testing.T) {
+		answers := strings.Join([]string{
+			"x",
+			"",
+			"s",
+			"t",
+		}, "\n")
+
+		output := bytes.Buffer{}
+		worklogs := newWorklogs()
+
+		actions, err := New(strings.NewReader(answers), &output, time.FixedZone("CET", 3600)).Run(worklogs)
+
+		assert.Errors(t, err, nil)
+		assert.StringSlices(t, actions, []string{ActionAccept, ActionSkip, ActionSkipAndTag, ActionAccept})
+		assert.Bools(t, strings.Contains(output.String(), `Unknown answer "x" - use a, s, t, i, c or d`), true)
+		assert.Bools(t, strings.Contains(output.String(), "Date: 2025-01-13 11:00"), true)
+		assert.Bools(t, strings.Contains(output.String(), "Time spent: 30m0s (clockify: 27m0s)"), true)
+		assert.Bools(t, strings.Contains(output.String(), "ABC-4 blocked by time cap"), true)
+	})
+
+	t.Run("Edit issue, comment and duration", func(t *testing.T) {
+		answers := strings.Join([]string{
+			"i",
+			"not an issue",
+			"xyz-12",
+			"c",
+			"fix logout",
+			"d",
+			"0",
+			"45",
+			"a",
+			"d",
+			"1h15m",
+			"",
+			"s",
+		}, "\n")
+
+		output := bytes.Buffer{}
+		worklogs := newWorklogs()
+
+		actions, err := New(strings.NewReader(answers), &output, time.UTC).Run(worklogs)
+
+		assert.Errors(t, err, nil)
+		assert.StringSlices(t, actions, []string{ActionAccept, ActionAccept, ActionSkip, ActionAccept})
+		assert.Strings(t, worklogs[0].IssueID, "XYZ-12")
+		assert.Strings(t, worklogs[0].Comment, "fix logout")
+		assert.Ints(t, worklogs[0].RoundedSeconds, 2700)
+		assert.Ints(t, worklogs[1].RoundedSeconds, 4500)
+		assert.Bools(t, strings.Contains(output.String(), `Invalid issue key "not an issue"`), true)
+		assert.Bools(t, strings.Contains(output.String(), `Invalid duration "0"`), true)
+	})
+
+	t.Run("Return error when input is closed", func(t *testing.T) {
+		output := bytes.Buffer{}
+
+		_, err := New(strings.NewReader("a\n"), &output, time.UTC).Run(newWorklogs())
+
+		assert.Errors(t, err, ErrReviewInputClosed)
+	})
+}
+
+func TestConfirm(t *testing.T) {
+
+	t.Run("Display totals of accepted worklogs", func(t *testing.T) {
+		output := bytes.Buffer{}
+		actions := []string{ActionAccept, ActionAccept, ActionSkipAndTag, ActionAccept}
+
+		confirmed, err := New(strings.NewReader("y\n"), &output, time.UTC).Confirm(newWorklogs(), actions)
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, confirmed, true)
+		assert.Bools(t, strings.Contains(output.String(), "Apply 2 worklogs - total 45m0s (clockify: 37m0s), tag 2 time entries as skipped, skip 0 worklogs?"), true)
+	})
+
+	t.Run("Don't apply by default", func(t *testing.T) {
+		output := bytes.Buffer{}
+		actions := []string{ActionAccept, ActionSkip, ActionSkip, ActionAccept}
+
+		confirmed, err := New(strings.NewReader("\n"), &output, time.UTC).Confirm(newWorklogs(), actions)
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, confirmed, false)
+	})
+
+	t.Run("Don't ask when nothing is accepted", func(t *testing.T) {
+		output := bytes.Buffer{}
+		actions := []string{ActionSkip, ActionSkip, ActionSkip, ActionAccept}
+
+		confirmed, err := New(strings.NewReader(""), &output, time.UTC).Confirm(newWorklogs(), actions)
+
+		assert.Errors(t, err, nil)
+		assert.Bools(t, confirmed, false)
+		assert.Strings(t, strings.TrimSpace(output.String()), "Nothing to apply")
+	})
+}
@@ -0,0 +1,122 @@
+package rounding
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	Nearest = "nearest"
+	Ceil    = "ceil"
+	Floor   = "floor"
+	Grace   = "grace"
+
+	ErrRoundingUnknownStrategy   = RoundingErr("Unknown rounding strategy - use nearest, ceil, floor or grace")
+	ErrRoundingInvalidPrecision  = RoundingErr("Rounding precision has to be greater than 0")
+	ErrRoundingInvalidGrace      = RoundingErr("Rounding grace has to be between 0 and precision")
+	ErrRoundingInvalidMinimum    = RoundingErr("Rounding minimum cannot be negative")
+	ErrRoundingNegativeTimeSpent = RoundingErr("Cannot round negative time spent")
+)
+
+var (
+	Strategies = []string{Nearest, Ceil, Floor, Grace}
+)
+
+type RoundingErr string
+
+func (e RoundingErr) Error() string {
+	return string(e)
+}
+
+type Options struct {
+	Strategy  string
+	Precision time.Duration
+	Grace     time.Duration
+	// Minimum defaults to one precision unit
+	Minimum time.Duration
+}
+
+func Round(timeSpent time.Duration, options Options) (time.Duration, error) {
+
+	err := options.validate()
+
+	if err != nil {
+		return 0, err
+	}
+
+	if timeSpent < 0 {
+		return 0, ErrRoundingNegativeTimeSpent
+	}
+
+	var rounded time.Duration
+
+	switch options.Strategy {
+	case Nearest, "":
+		rounded = timeSpent.Round(options.Precision)
+	case Ceil:
+		rounded = timeSpent.Truncate(options.Precision)
+
+		if rounded < timeSpent {
+			rounded += options.Precision
+		}
+	case Floor:
+		rounded = timeSpent.Truncate(options.Precision)
+	case Grace:
+		rounded = timeSpent.Truncate(options.Precision)
+
+		if timeSpent-rounded > options.Grace {
+			rounded += options.Precision
+		}
+	}
+
+	return max(rounded, options.minimum()), nil
+}
+
+func RoundAll(timeSpent time.Duration, options Options) (map[string]time.Duration, error) {
+
+	result := make(map[string]time.Duration, len(Strategies))
+
+	for _, strategy := range Strategies {
+		options.Strategy = strategy
+
+		rounded, err := Round(timeSpent, options)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result[strategy] = rounded
+	}
+
+	return result, nil
+}
+
+func (o Options) validate() error {
+
+	if o.Strategy != "" && !slices.Contains(Strategies, o.Strategy) {
+		return ErrRoundingUnknownStrategy
+	}
+
+	if o.Precision <= 0 {
+		return ErrRoundingInvalidPrecision
+	}
+
+	if o.Grace < 0 || o.Grace >= o.Precision {
+		return ErrRoundingInvalidGrace
+	}
+
+	if o.Minimum < 0 {
+		return ErrRoundingInvalidMinimum
+	}
+
+	return nil
+}
+
+func (o Options) minimum() time.Duration {
+
+	if o.Minimum == 0 {
+		return o.Precision
+	}
+
+	return o.Minimum
+}
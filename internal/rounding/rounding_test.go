@@ -0,0 +1,158 @@
+package rounding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+)
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeSpent time.Duration
+		options   Options
+		want      time.Duration
+	}{
+		{
+			name:      "Nearest - round down",
+			timeSpent: 22*time.Minute + 29*time.Second,
+			options:   Options{Strategy: Nearest, Precision: 15 * time.Minute},
+			want:      15 * time.Minute,
+		},
+		{
+			name:      "Nearest - round up",
+			timeSpent: 22*time.Minute + 30*time.Second,
+			options:   Options{Strategy: Nearest, Precision: 15 * time.Minute},
+			want:      30 * time.Minute,
+		},
+		{
+			name:      "Nearest is default strategy",
+			timeSpent: 22*time.Minute + 30*time.Second,
+			options:   Options{Precision: 15 * time.Minute},
+			want:      30 * time.Minute,
+		},
+		{
+			name:      "Nearest - zero becomes precision",
+			timeSpent: 2 * time.Minute,
+			options:   Options{Strategy: Nearest, Precision: 15 * time.Minute},
+			want:      15 * time.Minute,
+		},
+		{
+			name:      "Ceil - round up",
+			timeSpent: 16 * time.Minute,
+			options:   Options{Strategy: Ceil, Precision: 15 * time.Minute},
+			want:      30 * time.Minute,
+		},
+		{
+			name:      "Ceil - keep exact multiple",
+			timeSpent: 30 * time.Minute,
+			options:   Options{Strategy: Ceil, Precision: 15 * time.Minute},
+			want:      30 * time.Minute,
+		},
+		{
+			name:      "Floor - round down",
+			timeSpent: 29 * time.Minute,
+			options:   Options{Strategy: Floor, Precision: 15 * time.Minute},
+			want:      15 * time.Minute,
+		},
+		{
+			name:      "Grace - round down within grace",
+			timeSpent: 35 * time.Minute,
+			options:   Options{Strategy: Grace, Precision: 15 * time.Minute, Grace: 5 * time.Minute},
+			want:      30 * time.Minute,
+		},
+		{
+			name:      "Grace - round up after grace",
+			timeSpent: 35*time.Minute + time.Second,
+			options:   Options{Strategy: Grace, Precision: 15 * time.Minute, Grace: 5 * time.Minute},
+			want:      45 * time.Minute,
+		},
+		{
+			name:      "Custom minimum",
+			timeSpent: 3 * time.Minute,
+			options:   Options{Strategy: Ceil, Precision: 15 * time.Minute, Minimum: 30 * time.Minute},
+			want:      30 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Round(tt.timeSpent, tt.options)
+
+			assert.Errors(t, err, nil)
+			assert.Strings(t, got.String(), tt.want.String())
+		})
+	}
+}
+
+func TestRoundErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		timeSpent time.Duration
+		options   Options
+		want      error
+	}{
+		{
+			name:    "Unknown strategy",
+			options: Options{Strategy: "banker", Precision: time.Minute},
+			want:    ErrRoundingUnknownStrategy,
+		},
+		{
+			name:    "Zero precision",
+			options: Options{Strategy: Nearest},
+			want:    ErrRoundingInvalidPrecision,
+		},
+		{
+			name:    "Grace not smaller than precision",
+			options: Options{Strategy: Grace, Precision: time.Minute, Grace: time.Minute},
+			want:    ErrRoundingInvalidGrace,
+		},
+		{
+			name:    "Negative minimum",
+			options: Options{Strategy: Nearest, Precision: time.Minute, Minimum: -time.Minute},
+			want:    ErrRoundingInvalidMinimum,
+		},
+		{
+			name:      "Negative time spent",
+			timeSpent: -time.Minute,
+			options:   Options{Strategy: Nearest, Precision: time.Minute},
+			want:      ErrRoundingNegativeTimeSpent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Round(tt.timeSpent, tt.options)
+
+			assert.Errors(t, err, tt.want)
+		})
+	}
+}
+
+func TestRoundAll(t *testing.T) {
+
+	t.Run("Round with every strategy", func(t *testing.T) {
+		got, err := RoundAll(37*time.Minute, Options{Strategy: Floor, Precision: 15 * time.Minute, Grace: 5 * time.Minute})
+
+		assert.Errors(t, err, nil)
+		assert.Ints(t, len(got), len(Strategies))
+		assert.Strings(t, got[Nearest].String(), "30m0s")
+		assert.Strings(t, got[Ceil].String(), "45m0s")
+		assert.Strings(t, got[Floor].String(), "30m0s")
+		assert.Strings(t, got[Grace].String(), "45m0s")
+	})
+
+	t.Run("Return error on invalid options", func(t *testing.T) {
+		_, err := RoundAll(37*time.Minute, Options{})
+
+		assert.Errors(t, err, ErrRoundingInvalidPrecision)
+	})
+}
+
+func TestError(t *testing.T) {
+	t.Run("Return error message", func(t *testing.T) {
+		got := RoundingErr("Error message").Error()
+		want := "Error message"
+
+		assert.Strings(t, got, want)
+	})
+}
@@ -0,0 +1,258 @@
+package wizard
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/kruc/clockify-to-jira/internal/clockify"
+	"github.com/kruc/clockify-to-jira/internal/config"
+	"golang.org/x/term"
+)
+
+const (
+	ErrWizardInputClosed       = WizardErr("Configuration wizard input closed")
+	ErrWizardNoWorkspaces      = WizardErr("Cannot find clockify workspaces")
+	ErrWizardNoWorkspaceChosen = WizardErr("At least one workspace has to be chosen")
+)
+
+var (
+	nonAlphanumeric = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+type WizardErr string
+
+func (e WizardErr) Error() string {
+	return string(e)
+}
+
+// Directory lists clockify workspaces and clients available for the token
+type Directory interface {
+	GetWorkspaces() ([]clockify.Workspace, error)
+	GetWorkspaceClients(workspaceId string) ([]clockify.Client, error)
+}
+
+type Wizard struct {
+	input        *bufio.Scanner
+	output       io.Writer
+	readSecret   func() (string, error)
+	newDirectory func(clockifyToken string) (Directory, error)
+}
+
+// New creates wizard reading answers from input, secrets typed in a terminal are not echoed
+func New(input io.Reader, output io.Writer, newDirectory func(clockifyToken string) (Directory, error)) *Wizard {
+
+	wizard := &Wizard{
+		input:        bufio.NewScanner(input),
+		output:       output,
+		newDirectory: newDirectory,
+	}
+
+	if file, ok := input.(*os.File); ok && term.IsTerminal(int(file.Fd())) {
+		wizard.readSecret = func() (string, error) {
+			secret, err := term.ReadPassword(int(file.Fd()))
+			fmt.Fprintln(output)
+
+			return string(secret), err
+		}
+	}
+
+	return wizard
+}
+
+// Run asks for clockify token, lists workspaces and clients from clockify and asks for jira credentials of every enabled client
+func (w *Wizard) Run() (config.Config, error) {
+
+	clockifyToken, err := w.askRequired("Clockify token (visit https://app.clockify.me/user/preferences#advanced)")
+
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	directory, err := w.newDirectory(clockifyToken)
+
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	clockifyWorkspaces, err := directory.GetWorkspaces()
+
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	if len(clockifyWorkspaces) == 0 {
+		return config.Config{}, ErrWizardNoWorkspaces
+	}
+
+	workspaces := config.Workspaces{}
+	previous := config.Client{}
+
+	for _, clockifyWorkspace := range clockifyWorkspaces {
+
+		add, err := w.confirm(fmt.Sprintf("Add workspace %q?", clockifyWorkspace.Name), true)
+
+		if err != nil {
+			return config.Config{}, err
+		}
+
+		if !add {
+			continue
+		}
+
+		clockifyClients, err := directory.GetWorkspaceClients(clockifyWorkspace.ID)
+
+		if err != nil {
+			return config.Config{}, err
+		}
+
+		workspace := &config.Workspace{
+			WorkspaceId: clockifyWorkspace.ID,
+			Clients:     config.Clients{},
+		}
+
+		for _, clockifyClient := range clockifyClients {
+
+			client, err := w.askClient(clockifyClient.Name, &previous)
+
+			if err != nil {
+				return config.Config{}, err
+			}
+
+			client.ClockifyClientId = clockifyClient.ID
+			workspace.Clients[config.NormalizeClientName(clockifyClient.Name)] = client
+		}
+
+		workspaces[workspaceKey(clockifyWorkspace.Name)] = workspace
+	}
+
+	if len(workspaces) == 0 {
+		return config.Config{}, ErrWizardNoWorkspaceChosen
+	}
+
+	return config.NewConfigFromTemplate(clockifyToken, workspaces), nil
+}
+
+// askClient suggests jira settings of the previously enabled client as defaults
+func (w *Wizard) askClient(clientName string, previous *config.Client) (*config.Client, error) {
+
+	enabled, err := w.confirm(fmt.Sprintf("Enable client %q?", clientName), true)
+
+	if err != nil || !enabled {
+		return &config.Client{Enabled: false}, err
+	}
+
+	client := &config.Client{Enabled: true}
+
+	questions := []struct {
+		question string
+		value    *string
+		previous string
+		secret   bool
+	}{
+		{"Jira host (e.g. https://domain.atlassian.net)", &client.JiraHost, previous.JiraHost, false},
+		{"Jira username", &client.JiraUsername, previous.JiraUsername, false},
+		{"Jira password or api token (visit https://id.atlassian.com/manage/api-tokens)", &client.JiraPassword, previous.JiraPassword, true},
+	}
+
+	for _, question := range questions {
+		answer, err := w.ask(question.question, question.previous, question.secret)
+
+		if err != nil {
+			return nil, err
+		}
+
+		*question.value = answer
+	}
+
+	*previous = *client
+
+	return client, nil
+}
+
+func (w *Wizard) askRequired(question string) (string, error) {
+
+	for {
+		answer, err := w.ask(question, "", true)
+
+		if err != nil || answer != "" {
+			return answer, err
+		}
+	}
+}
+
+// ask never prints secret default answers
+func (w *Wizard) ask(question, defaultAnswer string, secret bool) (string, error) {
+
+	if defaultAnswer != "" && secret {
+		fmt.Fprintf(w.output, "%s [previous]: ", question)
+	} else if defaultAnswer != "" {
+		fmt.Fprintf(w.output, "%s [%s]: ", question, defaultAnswer)
+	} else {
+		fmt.Fprintf(w.output, "%s: ", question)
+	}
+
+	answer, err := w.readAnswer(secret)
+
+	if err != nil {
+		return "", err
+	}
+
+	if answer == "" {
+		return defaultAnswer, nil
+	}
+
+	return answer, nil
+}
+
+func (w *Wizard) readAnswer(secret bool) (string, error) {
+
+	if secret && w.readSecret != nil {
+		answer, err := w.readSecret()
+
+		if err != nil {
+			return "", ErrWizardInputClosed
+		}
+
+		return strings.TrimSpace(answer), nil
+	}
+
+	if !w.input.Scan() {
+		return "", ErrWizardInputClosed
+	}
+
+	return strings.TrimSpace(w.input.Text()), nil
+}
+
+func (w *Wizard) confirm(question string, defaultAnswer bool) (bool, error) {
+
+	hint := "Y/n"
+
+	if !defaultAnswer {
+		hint = "y/N"
+	}
+
+	for {
+		fmt.Fprintf(w.output, "%s [%s]: ", question, hint)
+
+		if !w.input.Scan() {
+			return false, ErrWizardInputClosed
+		}
+
+		switch strings.ToLower(strings.TrimSpace(w.input.Text())) {
+		case "":
+			return defaultAnswer, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+	}
+}
+
+func workspaceKey(name string) string {
+	return strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(name), "_"), "_")
+}
@@ -0,0 +1,128 @@
+package wizard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+	"github.com/kruc/clockify-to-jira/internal/clockify"
+)
+
+type fakeDirectory struct {
+	workspaces []clockify.Workspace
+	clients    map[string][]clockify.Client
+}
+
+func (f *fakeDirectory) GetWorkspaces() ([]clockify.Workspace, error) {
+	return f.workspaces, nil
+}
+
+func (f *fakeDirectory) GetWorkspaceClients(workspaceId string) ([]clockify.Client, error) {
+	return f.clients[workspaceId], nil
+}
+
+func newFakeDirectory(clockifyToken string) (Directory, error) {
+	return &fakeDirectory{
+		workspaces: []clockify.Workspace{
+			{ID: "ws-1", Name: "My Company"},
+			{ID: "ws-2", Name: "Side Projects"},
+		},
+		clients: map[string][]clockify.Client{
+			"ws-1": {{ID: "acme-id", Name: "ACME"}, {Name: "Globex"}, {Name: "Internal"}},
+		},
+	}, nil
+}
+
+func TestRun(t *testing.T) {
+
+	t.Run("Generate config from answers", func(t *testing.T) {
+		answers := strings.Join([]string{
+			"",
+			"clockify-token",
+			"",
+			"y",
+			"https://acme.atlassian.net",
+			"user@acme.com",
+			"acme-token",
+			"yes",
+			"https://globex.atlassian.net",
+			"",
+			"",
+			"n",
+			"n",
+		}, "\n")
+
+		output := bytes.Buffer{}
+		wizard := New(strings.NewReader(answers), &output, newFakeDirectory)
+
+		config, err := wizard.Run()
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, config.Global.ClockifyToken, "clockify-token")
+		assert.Ints(t, len(config.Workspaces), 1)
+
+		workspace := config.Workspaces["my_company"]
+		assert.Strings(t, workspace.WorkspaceId, "ws-1")
+		assert.Strings(t, config.DefaultWorkspace.JiraMigrationSuccessTag, "logged")
+		assert.Ints(t, len(workspace.Clients), 3)
+
+		acme := workspace.Clients["acme"]
+		assert.Bools(t, acme.Enabled, true)
+		assert.Strings(t, acme.ClockifyClientId, "acme-id")
+		assert.Strings(t, acme.JiraHost, "https://acme.atlassian.net")
+		assert.Strings(t, acme.JiraUsername, "user@acme.com")
+		assert.Strings(t, acme.JiraPassword, "acme-token")
+
+		globex := workspace.Clients["globex"]
+		assert.Strings(t, globex.JiraHost, "https://globex.atlassian.net")
+		assert.Strings(t, globex.JiraUsername, "user@acme.com")
+		assert.Strings(t, globex.JiraPassword, "acme-token")
+
+		assert.Bools(t, workspace.Clients["internal"].Enabled, false)
+
+		assert.Bools(t, strings.Contains(output.String(), "acme-token"), false)
+		assert.Bools(t, strings.Contains(output.String(), "Jira username [user@acme.com]: "), true)
+	})
+
+	t.Run("Read secrets without echo", func(t *testing.T) {
+		secrets := []string{"clockify-token", "acme-token"}
+		answers := strings.Join([]string{"", "y", "https://acme.atlassian.net", "user@acme.com", "n", "n", "n"}, "\n")
+
+		output := bytes.Buffer{}
+		wizard := New(strings.NewReader(answers), &output, newFakeDirectory)
+		wizard.readSecret = func() (string, error) {
+			secret := secrets[0]
+			secrets = secrets[1:]
+
+			return secret, nil
+		}
+
+		config, err := wizard.Run()
+
+		assert.Errors(t, err, nil)
+		assert.Strings(t, config.Global.ClockifyToken, "clockify-token")
+		assert.Strings(t, config.Workspaces["my_company"].Clients["acme"].JiraPassword, "acme-token")
+		assert.Ints(t, len(secrets), 0)
+	})
+
+	t.Run("Throw error when input is closed", func(t *testing.T) {
+		wizard := New(strings.NewReader("clockify-token\n"), &bytes.Buffer{}, newFakeDirectory)
+
+		_, err := wizard.Run()
+
+		assert.Errors(t, err, ErrWizardInputClosed)
+	})
+
+	t.Run("Throw error when no workspace is chosen", func(t *testing.T) {
+		wizard := New(strings.NewReader("clockify-token\nn\nn\n"), &bytes.Buffer{}, newFakeDirectory)
+
+		_, err := wizard.Run()
+
+		assert.Errors(t, err, ErrWizardNoWorkspaceChosen)
+	})
+}
+
+func TestWorkspaceKey(t *testing.T) {
+	assert.Strings(t, workspaceKey(" My Company (EU) "), "my_company_eu")
+}
@@ -0,0 +1,53 @@
+package worklog
+
+import (
+	"time"
+)
+
+// MergeAdjacent merges consecutive worklogs of the same issue separated by less than client merge_gap
+func MergeAdjacent(worklogs []Worklog) []Worklog {
+
+	result := []Worklog{}
+
+	for _, worklog := range worklogs {
+
+		last := len(result) - 1
+
+		if last >= 0 && result[last].isAdjacentTo(worklog) {
+			comment := mergeComments(result[last].Comment, worklog.Comment)
+
+			result[last] = merge(result[last], worklog)
+			result[last].Comment = comment
+
+			continue
+		}
+
+		result = append(result, worklog)
+	}
+
+	return result
+}
+
+func (w *Worklog) Ended() time.Time {
+
+	ended := w.Started
+
+	for _, timeEntry := range w.TimeEntries {
+		if timeEntry.End != nil && timeEntry.End.After(ended) {
+			ended = *timeEntry.End
+		}
+	}
+
+	return ended
+}
+
+func (w *Worklog) isAdjacentTo(next Worklog) bool {
+
+	if w.Client.MergeGap <= 0 || w.ClientID != next.ClientID || w.IssueID != next.IssueID {
+		return false
+	}
+
+	gap := next.Started.Sub(w.Ended())
+
+	return gap < time.Duration(w.Client.MergeGap)*time.Minute
+}
@@ -0,0 +1,83 @@
+package worklog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+	"github.com/kruc/clockify-to-jira/internal/clockify"
+	"github.com/kruc/clockify-to-jira/internal/config"
+)
+
+func newTimedWorklog(id string, client *config.Client, issueID, comment string, start time.Time, minutes int) Worklog {
+	end := start.Add(time.Duration(minutes) * time.Minute)
+
+	return Worklog{
+		ClientID:         "client1",
+		Client:           client,
+		IssueID:          issueID,
+		Comment:          comment,
+		Started:          start,
+		TimeSpentSeconds: minutes * 60,
+		TimeEntries: []clockify.TimeEntry{
+			{ID: id, Start: start, End: &end},
+		},
+	}
+}
+
+func TestMergeAdjacent(t *testing.T) {
+
+	start := time.Date(2025, time.January, 8, 10, 0, 0, 0, time.Local)
+	client := &config.Client{MergeGap: 5}
+
+	t.Run("Merge worklogs separated by less than merge gap", func(t *testing.T) {
+		worklogs := []Worklog{
+			newTimedWorklog("id1", client, "ABC-1", "fix", start, 20),
+			newTimedWorklog("id2", client, "ABC-1", "fix", start.Add(22*time.Minute), 10),
+			newTimedWorklog("id3", client, "ABC-1", "tests", start.Add(36*time.Minute), 10),
+		}
+
+		got := MergeAdjacent(worklogs)
+
+		assert.Ints(t, len(got), 1)
+		assert.Ints(t, got[0].TimeSpentSeconds, 2400)
+		assert.Strings(t, got[0].Comment, "fix; tests")
+		assert.Strings(t, got[0].Started.String(), start.String())
+		assert.Strings(t, got[0].Ended().String(), start.Add(46*time.Minute).String())
+		assert.StringSlices(t, got[0].TimeEntryIDs(), []string{"id1", "id2", "id3"})
+	})
+
+	t.Run("Keep worklogs separated by merge gap or more", func(t *testing.T) {
+		worklogs := []Worklog{
+			newTimedWorklog("id1", client, "ABC-1", "fix", start, 20),
+			newTimedWorklog("id2", client, "ABC-1", "fix", start.Add(25*time.Minute), 10),
+		}
+
+		got := MergeAdjacent(worklogs)
+
+		assert.Ints(t, len(got), 2)
+	})
+
+	t.Run("Keep worklogs of different issues", func(t *testing.T) {
+		worklogs := []Worklog{
+			newTimedWorklog("id1", client, "ABC-1", "fix", start, 20),
+			newTimedWorklog("id2", client, "ABC-2", "fix", start.Add(20*time.Minute), 10),
+			newTimedWorklog("id3", client, "ABC-1", "fix", start.Add(30*time.Minute), 10),
+		}
+
+		got := MergeAdjacent(worklogs)
+
+		assert.Ints(t, len(got), 3)
+	})
+
+	t.Run("Keep worklogs of clients without merge gap", func(t *testing.T) {
+		worklogs := []Worklog{
+			newTimedWorklog("id1", separateClient, "ABC-1", "fix", start, 20),
+			newTimedWorklog("id2", separateClient, "ABC-1", "fix", start.Add(20*time.Minute), 10),
+		}
+
+		got := MergeAdjacent(worklogs)
+
+		assert.Ints(t, len(got), 2)
+	})
+}
@@ -0,0 +1,209 @@
+package worklog
+
+import (
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/period"
+)
+
+const (
+	CapActionWarn  = "warn"
+	CapActionBlock = "block"
+	CapActionScale = "scale"
+
+	GlobalCapScope = "global"
+
+	defaultPrecisionSeconds = 60
+)
+
+// Cap limits rounded time logged per day and per week (minutes)
+type Cap struct {
+	Daily  int
+	Weekly int
+	Action string
+}
+
+// CapCheck is a cap of the period, total includes time logged in previous runs
+type CapCheck struct {
+	Scope         string
+	Period        string
+	TotalSeconds  int
+	LoggedSeconds int
+	CapSeconds    int
+	Action        string
+}
+
+func (c CapCheck) Exceeded() bool {
+	return c.TotalSeconds > c.CapSeconds
+}
+
+// ApplyCaps checks rounded time of every client and of all worklogs against configured caps.
+// Already logged worklogs count towards the totals, worklogs exceeding a cap are blocked or scaled down proportionally, depending on cap action.
+func ApplyCaps(worklogs, loggedWorklogs []Worklog, globalCap Cap, calendar period.Calendar) []CapCheck {
+
+	checks := []CapCheck{}
+	clientScopes := []string{}
+	clientCaps := map[string]Cap{}
+
+	for _, worklog := range worklogs {
+		scope := worklog.clientScope()
+
+		if _, ok := clientCaps[scope]; !ok {
+			clientScopes = append(clientScopes, scope)
+			clientCaps[scope] = Cap{
+				Daily:  worklog.Client.DailyCap,
+				Weekly: worklog.Client.WeeklyCap,
+				Action: worklog.Client.CapAction,
+			}
+		}
+	}
+
+	for _, scope := range clientScopes {
+		inScope := func(w *Worklog) bool { return w.clientScope() == scope }
+
+		checks = append(checks, applyCap(worklogs, loggedWorklogs, scope, clientCaps[scope], inScope, calendar)...)
+	}
+
+	inGlobalScope := func(w *Worklog) bool { return true }
+
+	return append(checks, applyCap(worklogs, loggedWorklogs, GlobalCapScope, globalCap, inGlobalScope, calendar)...)
+}
+
+// CapWindow returns range of time counted by caps of given worklogs - from the week start of the oldest worklog to the end of the day of the newest one
+func CapWindow(worklogs []Worklog, calendar period.Calendar) (time.Time, time.Time) {
+
+	var start, end time.Time
+
+	for index, worklog := range worklogs {
+		weekStart := calendar.StartOfWeek(worklog.Started)
+		dayEnd := calendar.StartOfDay(worklog.Started).AddDate(0, 0, 1)
+
+		if index == 0 || weekStart.Before(start) {
+			start = weekStart
+		}
+
+		if index == 0 || dayEnd.After(end) {
+			end = dayEnd
+		}
+	}
+
+	return start, end
+}
+
+func applyCap(worklogs, loggedWorklogs []Worklog, scope string, timeCap Cap, inScope func(*Worklog) bool, calendar period.Calendar) []CapCheck {
+
+	checks := []CapCheck{}
+
+	periods := []struct {
+		limit  int
+		period func(*Worklog) string
+	}{
+		{timeCap.Daily, func(w *Worklog) string { return w.Day(calendar) }},
+		{timeCap.Weekly, func(w *Worklog) string { return w.Week(calendar) }},
+	}
+
+	for _, p := range periods {
+
+		if p.limit <= 0 {
+			continue
+		}
+
+		groups := []string{}
+		totals := map[string]int{}
+		loggedTotals := map[string]int{}
+
+		for index := range worklogs {
+			if !inScope(&worklogs[index]) || worklogs[index].Blocked {
+				continue
+			}
+
+			period := p.period(&worklogs[index])
+
+			if _, ok := totals[period]; !ok {
+				groups = append(groups, period)
+			}
+
+			totals[period] += worklogs[index].RoundedSeconds
+		}
+
+		for index := range loggedWorklogs {
+			if inScope(&loggedWorklogs[index]) {
+				loggedTotals[p.period(&loggedWorklogs[index])] += loggedWorklogs[index].RoundedSeconds
+			}
+		}
+
+		for _, period := range groups {
+			check := CapCheck{
+				Scope:         scope,
+				Period:        period,
+				TotalSeconds:  totals[period] + loggedTotals[period],
+				LoggedSeconds: loggedTotals[period],
+				CapSeconds:    p.limit * int(time.Minute.Seconds()),
+				Action:        timeCap.action(),
+			}
+
+			checks = append(checks, check)
+
+			if !check.Exceeded() || check.Action == CapActionWarn {
+				continue
+			}
+
+			for index := range worklogs {
+				worklog := &worklogs[index]
+
+				if !inScope(worklog) || worklog.Blocked || p.period(worklog) != period {
+					continue
+				}
+
+				if check.Action == CapActionScale {
+					worklog.RoundedSeconds = scale(worklog.RoundedSeconds, check.CapSeconds-check.LoggedSeconds, totals[period], worklog.precisionSeconds())
+				}
+
+				// nothing left to log in the period
+				if check.Action == CapActionBlock || worklog.RoundedSeconds == 0 {
+					worklog.Blocked = true
+				}
+			}
+		}
+	}
+
+	return checks
+}
+
+func (c Cap) action() string {
+
+	if c.Action == "" {
+		return CapActionWarn
+	}
+
+	return c.Action
+}
+
+func (w *Worklog) Week(calendar period.Calendar) string {
+	return calendar.Week(w.Started)
+}
+
+func (w *Worklog) clientScope() string {
+	return w.Workspace + "/" + w.ClientID
+}
+
+func (w *Worklog) precisionSeconds() int {
+
+	if w.Client == nil || w.Client.StachurskyMode <= 0 {
+		return defaultPrecisionSeconds
+	}
+
+	return w.Client.StachurskyMode * int(time.Minute.Seconds())
+}
+
+// scale reduces seconds proportionally to fit available time, rounded down to whole precision units
+func scale(seconds, availableSeconds, totalSeconds, precisionSeconds int) int {
+
+	if availableSeconds <= 0 {
+		return 0
+	}
+
+	scaled := seconds * availableSeconds / totalSeconds
+
+	return scaled - scaled%precisionSeconds
+}
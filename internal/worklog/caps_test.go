@@ -0,0 +1,185 @@
+package worklog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+	"github.com/kruc/clockify-to-jira/internal/config"
+	"github.com/kruc/clockify-to-jira/internal/period"
+)
+
+func newRoundedWorklog(workspace, clientID string, client *config.Client, started time.Time, roundedSeconds int) Worklog {
+	return Worklog{
+		Workspace:      workspace,
+		ClientID:       clientID,
+		Client:         client,
+		Started:        started,
+		RoundedSeconds: roundedSeconds,
+	}
+}
+
+func TestApplyCaps(t *testing.T) {
+
+	monday := time.Date(2025, time.January, 6, 10, 0, 0, 0, time.Local)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	t.Run("Warn about exceeded client daily cap", func(t *testing.T) {
+		client := &config.Client{DailyCap: 480}
+		worklogs := []Worklog{
+			newRoundedWorklog("ws", "client1", client, monday, 6*3600),
+			newRoundedWorklog("ws", "client1", client, monday, 3*3600),
+			newRoundedWorklog("ws", "client1", client, tuesday, 3*3600),
+		}
+
+		checks := ApplyCaps(worklogs, nil, Cap{}, localCalendar)
+
+		assert.Ints(t, len(checks), 2)
+		assert.Strings(t, checks[0].Scope, "ws/client1")
+		assert.Strings(t, checks[0].Period, "2025-01-06")
+		assert.Ints(t, checks[0].TotalSeconds, 9*3600)
+		assert.Ints(t, checks[0].CapSeconds, 8*3600)
+		assert.Strings(t, checks[0].Action, CapActionWarn)
+		assert.Bools(t, checks[0].Exceeded(), true)
+		assert.Bools(t, checks[1].Exceeded(), false)
+		assert.Ints(t, worklogs[0].RoundedSeconds, 6*3600)
+		assert.Bools(t, worklogs[0].Blocked, false)
+	})
+
+	t.Run("Block worklogs of the day exceeding client daily cap", func(t *testing.T) {
+		client := &config.Client{DailyCap: 480, CapAction: CapActionBlock}
+		worklogs := []Worklog{
+			newRoundedWorklog("ws", "client1", client, monday, 6*3600),
+			newRoundedWorklog("ws", "client1", client, monday, 3*3600),
+			newRoundedWorklog("ws", "client1", client, tuesday, 3*3600),
+		}
+
+		ApplyCaps(worklogs, nil, Cap{}, localCalendar)
+
+		assert.Bools(t, worklogs[0].Blocked, true)
+		assert.Bools(t, worklogs[1].Blocked, true)
+		assert.Bools(t, worklogs[2].Blocked, false)
+	})
+
+	t.Run("Scale worklogs exceeding global weekly cap", func(t *testing.T) {
+		worklogs := []Worklog{
+			newRoundedWorklog("ws1", "client1", separateClient, monday, 6*3600),
+			newRoundedWorklog("ws2", "client2", separateClient, tuesday, 4*3600),
+		}
+
+		checks := ApplyCaps(worklogs, nil, Cap{Weekly: 300, Action: CapActionScale}, localCalendar)
+
+		assert.Ints(t, len(checks), 1)
+		assert.Strings(t, checks[0].Scope, GlobalCapScope)
+		assert.Strings(t, checks[0].Period, "week 2025-01-06")
+		assert.Ints(t, worklogs[0].RoundedSeconds, 3*3600)
+		assert.Ints(t, worklogs[1].RoundedSeconds, 2*3600)
+	})
+
+	t.Run("Count already logged time of the week", func(t *testing.T) {
+		client := &config.Client{WeeklyCap: 600, CapAction: CapActionBlock}
+		logged := []Worklog{
+			newRoundedWorklog("ws", "client1", client, monday, 8*3600),
+		}
+		worklogs := []Worklog{
+			newRoundedWorklog("ws", "client1", client, tuesday, 3*3600),
+		}
+
+		checks := ApplyCaps(worklogs, logged, Cap{}, localCalendar)
+
+		assert.Ints(t, len(checks), 1)
+		assert.Ints(t, checks[0].TotalSeconds, 11*3600)
+		assert.Ints(t, checks[0].LoggedSeconds, 8*3600)
+		assert.Bools(t, worklogs[0].Blocked, true)
+	})
+
+	t.Run("Scale worklogs to time left in precision units", func(t *testing.T) {
+		client := &config.Client{DailyCap: 480, CapAction: CapActionScale, StachurskyMode: 15}
+		logged := []Worklog{
+			newRoundedWorklog("ws", "client1", client, monday, 6*3600),
+		}
+		worklogs := []Worklog{
+			newRoundedWorklog("ws", "client1", client, monday, 2*3600),
+			newRoundedWorklog("ws", "client1", client, monday, 3600),
+		}
+
+		ApplyCaps(worklogs, logged, Cap{}, localCalendar)
+
+		assert.Ints(t, worklogs[0].RoundedSeconds, 4500)
+		assert.Ints(t, worklogs[1].RoundedSeconds, 1800)
+		assert.Bools(t, worklogs[0].Blocked, false)
+	})
+
+	t.Run("Block scaled worklogs when cap is used up by logged time", func(t *testing.T) {
+		client := &config.Client{DailyCap: 480, CapAction: CapActionScale}
+		logged := []Worklog{
+			newRoundedWorklog("ws", "client1", client, monday, 8*3600),
+		}
+		worklogs := []Worklog{
+			newRoundedWorklog("ws", "client1", client, monday, 3600),
+		}
+
+		ApplyCaps(worklogs, logged, Cap{}, localCalendar)
+
+		assert.Bools(t, worklogs[0].Blocked, true)
+	})
+
+	t.Run("Check global cap against scaled client totals", func(t *testing.T) {
+		client := &config.Client{DailyCap: 60, CapAction: CapActionScale}
+		worklogs := []Worklog{
+			newRoundedWorklog("ws", "client1", client, monday, 7200),
+			newRoundedWorklog("ws", "client2", separateClient, monday, 3600),
+		}
+
+		checks := ApplyCaps(worklogs, nil, Cap{Daily: 150}, localCalendar)
+
+		assert.Ints(t, len(checks), 2)
+		assert.Ints(t, worklogs[0].RoundedSeconds, 3600)
+		assert.Ints(t, checks[1].TotalSeconds, 7200)
+		assert.Bools(t, checks[1].Exceeded(), false)
+	})
+	t.Run("Group days and weeks in calendar timezone", func(t *testing.T) {
+		location, _ := time.LoadLocation("Europe/Warsaw")
+		calendar := period.Calendar{Location: location, WeekStart: time.Sunday}
+		client := &config.Client{DailyCap: 60, WeeklyCap: 600}
+		// sunday in UTC is already monday in Warsaw
+		worklogs := []Worklog{
+			newRoundedWorklog("ws", "client1", client, time.Date(2025, time.January, 12, 23, 30, 0, 0, time.UTC), 3600),
+		}
+
+		checks := ApplyCaps(worklogs, nil, Cap{}, calendar)
+
+		assert.Ints(t, len(checks), 2)
+		assert.Strings(t, checks[0].Period, "2025-01-13")
+		assert.Strings(t, checks[1].Period, "week 2025-01-12")
+	})
+}
+
+func TestScale(t *testing.T) {
+	assert.Ints(t, scale(3600, 28800, 39600, 60), 2580)
+	assert.Ints(t, scale(3600, 28800, 39600, 900), 1800)
+	assert.Ints(t, scale(60, 100, 1000000, 60), 0)
+	assert.Ints(t, scale(3600, -900, 3600, 60), 0)
+}
+
+func TestCapWindow(t *testing.T) {
+
+	wednesday := time.Date(2025, time.January, 8, 10, 0, 0, 0, time.Local)
+	worklogs := []Worklog{
+		newRoundedWorklog("ws", "client1", separateClient, wednesday, 3600),
+		newRoundedWorklog("ws", "client1", separateClient, wednesday.AddDate(0, 0, 2), 3600),
+	}
+
+	start, end := CapWindow(worklogs, localCalendar)
+
+	assert.Strings(t, start.String(), time.Date(2025, time.January, 6, 0, 0, 0, 0, time.Local).String())
+	assert.Strings(t, end.String(), time.Date(2025, time.January, 11, 0, 0, 0, 0, time.Local).String())
+
+	t.Run("Start window on configured week start", func(t *testing.T) {
+		sundayCalendar := period.Calendar{Location: time.Local, WeekStart: time.Sunday}
+
+		start, _ := CapWindow(worklogs, sundayCalendar)
+
+		assert.Strings(t, start.String(), time.Date(2025, time.January, 5, 0, 0, 0, 0, time.Local).String())
+	})
+}
@@ -0,0 +1,72 @@
+package worklog
+
+const (
+	MinDurationActionSkip  = "skip"
+	MinDurationActionMerge = "merge"
+	MinDurationActionLog   = "log"
+)
+
+// ApplyMinDuration separates worklogs shorter than client min_duration.
+// Depending on min_duration_action they are ignored (skip), merged into the next worklog of the same issue (merge) or kept (log).
+// Worklogs without next worklog to merge into are returned as waiting - a later run can merge them.
+func ApplyMinDuration(worklogs []Worklog) ([]Worklog, []Worklog, []Worklog) {
+
+	kept := []Worklog{}
+	ignored := []Worklog{}
+	waiting := []Worklog{}
+	pending := map[string]Worklog{}
+
+	for _, worklog := range worklogs {
+
+		key := worklog.ClientID + "/" + worklog.IssueID
+
+		if tooShort, ok := pending[key]; ok {
+			tooShort.Comment = mergeComments(tooShort.Comment, worklog.Comment)
+			worklog = merge(tooShort, worklog)
+
+			delete(pending, key)
+		}
+
+		if !worklog.isShorterThanMinDuration() {
+			kept = append(kept, worklog)
+			continue
+		}
+
+		switch worklog.Client.MinDurationAction {
+		case MinDurationActionLog:
+			kept = append(kept, worklog)
+		case MinDurationActionMerge:
+			pending[key] = worklog
+		default:
+			ignored = append(ignored, worklog)
+		}
+	}
+
+	for _, worklog := range worklogs {
+		key := worklog.ClientID + "/" + worklog.IssueID
+
+		if tooShort, ok := pending[key]; ok {
+			waiting = append(waiting, tooShort)
+			delete(pending, key)
+		}
+	}
+
+	return kept, ignored, waiting
+}
+
+func (w *Worklog) isShorterThanMinDuration() bool {
+	return w.TimeSpentSeconds < w.Client.MinDuration
+}
+
+func mergeComments(first, second string) string {
+
+	if first == "" || first == second {
+		return second
+	}
+
+	if second == "" {
+		return first
+	}
+
+	return first + "; " + second
+}
@@ -0,0 +1,90 @@
+package worklog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+	"github.com/kruc/clockify-to-jira/internal/config"
+)
+
+func TestApplyMinDuration(t *testing.T) {
+
+	started := time.Date(2025, time.January, 8, 10, 0, 0, 0, time.Local)
+
+	t.Run("Skip worklogs shorter than min duration", func(t *testing.T) {
+		client := &config.Client{MinDuration: 60}
+		worklogs := []Worklog{
+			newWorklog("id1", "client1", client, "ABC-1", "fix", started, 5),
+			newWorklog("id2", "client1", client, "ABC-1", "fix", started, 60),
+		}
+
+		kept, ignored, waiting := ApplyMinDuration(worklogs)
+
+		assert.Ints(t, len(kept), 1)
+		assert.Ints(t, len(ignored), 1)
+		assert.Ints(t, len(waiting), 0)
+		assert.StringSlices(t, kept[0].TimeEntryIDs(), []string{"id2"})
+		assert.StringSlices(t, ignored[0].TimeEntryIDs(), []string{"id1"})
+	})
+
+	t.Run("Log worklogs shorter than min duration", func(t *testing.T) {
+		client := &config.Client{MinDuration: 60, MinDurationAction: MinDurationActionLog}
+		worklogs := []Worklog{
+			newWorklog("id1", "client1", client, "ABC-1", "fix", started, 5),
+		}
+
+		kept, ignored, waiting := ApplyMinDuration(worklogs)
+
+		assert.Ints(t, len(kept), 1)
+		assert.Ints(t, len(ignored), 0)
+		assert.Ints(t, len(waiting), 0)
+	})
+
+	t.Run("Merge worklogs shorter than min duration into next worklog of the same issue", func(t *testing.T) {
+		client := &config.Client{MinDuration: 60, MinDurationAction: MinDurationActionMerge}
+		worklogs := []Worklog{
+			newWorklog("id1", "client1", client, "ABC-1", "start", started, 5),
+			newWorklog("id2", "client1", client, "ABC-2", "other", started.Add(time.Minute), 600),
+			newWorklog("id3", "client1", client, "ABC-1", "restart", started.Add(2*time.Minute), 10),
+			newWorklog("id4", "client1", client, "ABC-1", "fix", started.Add(3*time.Minute), 600),
+		}
+
+		kept, ignored, waiting := ApplyMinDuration(worklogs)
+
+		assert.Ints(t, len(kept), 2)
+		assert.Ints(t, len(ignored), 0)
+		assert.Ints(t, len(waiting), 0)
+		assert.StringSlices(t, kept[1].TimeEntryIDs(), []string{"id1", "id3", "id4"})
+		assert.Ints(t, kept[1].TimeSpentSeconds, 615)
+		assert.Strings(t, kept[1].Started.String(), started.String())
+		assert.Strings(t, kept[1].Comment, "start; restart; fix")
+	})
+
+	t.Run("Keep worklogs which cannot be merged yet waiting", func(t *testing.T) {
+		client := &config.Client{MinDuration: 60, MinDurationAction: MinDurationActionMerge}
+		worklogs := []Worklog{
+			newWorklog("id1", "client1", client, "ABC-1", "fix", started, 5),
+			newWorklog("id2", "client1", client, "ABC-2", "other", started, 600),
+		}
+
+		kept, ignored, waiting := ApplyMinDuration(worklogs)
+
+		assert.Ints(t, len(kept), 1)
+		assert.Ints(t, len(ignored), 0)
+		assert.Ints(t, len(waiting), 1)
+		assert.StringSlices(t, waiting[0].TimeEntryIDs(), []string{"id1"})
+	})
+
+	t.Run("Keep all worklogs without min duration", func(t *testing.T) {
+		worklogs := []Worklog{
+			newWorklog("id1", "client1", separateClient, "ABC-1", "fix", started, 1),
+		}
+
+		kept, ignored, waiting := ApplyMinDuration(worklogs)
+
+		assert.Ints(t, len(kept), 1)
+		assert.Ints(t, len(ignored), 0)
+		assert.Ints(t, len(waiting), 0)
+	})
+}
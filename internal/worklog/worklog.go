@@ -0,0 +1,128 @@
+package worklog
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/clockify"
+	"github.com/kruc/clockify-to-jira/internal/config"
+	"github.com/kruc/clockify-to-jira/internal/period"
+)
+
+const (
+	AggregationNone     = "none"
+	AggregationIssueDay = "issue_day"
+)
+
+// Worklog is a single jira worklog planned from one or more clockify time entries
+type Worklog struct {
+	Workspace        string
+	ClientID         string
+	Client           *config.Client
+	ClientRule       string
+	Project          string
+	IssueID          string
+	Comment          string
+	Started          time.Time
+	TimeSpentSeconds int
+	RoundedSeconds   int
+	Blocked          bool
+	TimeEntries      []clockify.TimeEntry
+}
+
+func (w *Worklog) Day(calendar period.Calendar) string {
+	return calendar.Day(w.Started)
+}
+
+func (w *Worklog) TimeEntryIDs() []string {
+
+	ids := make([]string, 0, len(w.TimeEntries))
+
+	for _, timeEntry := range w.TimeEntries {
+		ids = append(ids, timeEntry.ID)
+	}
+
+	return ids
+}
+
+func (w *Worklog) Description() string {
+
+	descriptions := make([]string, 0, len(w.TimeEntries))
+
+	for _, timeEntry := range w.TimeEntries {
+		descriptions = append(descriptions, timeEntry.Description)
+	}
+
+	return strings.Join(descriptions, " | ")
+}
+
+func (w *Worklog) TagNames() []string {
+
+	tagNames := []string{}
+
+	for _, timeEntry := range w.TimeEntries {
+		for _, tagName := range timeEntry.GetTagNamesList() {
+			if !slices.Contains(tagNames, tagName) {
+				tagNames = append(tagNames, tagName)
+			}
+		}
+	}
+
+	slices.Sort(tagNames)
+
+	return tagNames
+}
+
+// AggregateByIssueAndDay merges worklogs of clients with issue_day aggregation into a single worklog per issue and calendar day
+func AggregateByIssueAndDay(worklogs []Worklog, calendar period.Calendar) []Worklog {
+
+	result := []Worklog{}
+	comments := map[int][]string{}
+	groups := map[string]int{}
+
+	for _, worklog := range worklogs {
+
+		if worklog.Client.Aggregation != AggregationIssueDay {
+			result = append(result, worklog)
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s/%s", worklog.ClientID, worklog.IssueID, worklog.Day(calendar))
+		index, ok := groups[key]
+
+		if !ok {
+			index = len(result)
+			groups[key] = index
+			result = append(result, worklog)
+		} else {
+			result[index] = merge(result[index], worklog)
+		}
+
+		if worklog.Comment != "" && !slices.Contains(comments[index], worklog.Comment) {
+			comments[index] = append(comments[index], worklog.Comment)
+		}
+	}
+
+	for index := range result {
+		if len(result[index].TimeEntries) > 1 {
+			comment := fmt.Sprintf("%s\n(clockify: %s)", strings.Join(comments[index], "; "), strings.Join(result[index].TimeEntryIDs(), ", "))
+			result[index].Comment = strings.TrimSpace(comment)
+		}
+	}
+
+	return result
+}
+
+func merge(target, source Worklog) Worklog {
+
+	if source.Started.Before(target.Started) {
+		target.Started = source.Started
+	}
+
+	target.TimeSpentSeconds += source.TimeSpentSeconds
+	target.TimeEntries = append(slices.Clone(target.TimeEntries), source.TimeEntries...)
+
+	return target
+}
@@ -0,0 +1,97 @@
+package worklog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/assert"
+	"github.com/kruc/clockify-to-jira/internal/clockify"
+	"github.com/kruc/clockify-to-jira/internal/config"
+	"github.com/kruc/clockify-to-jira/internal/period"
+)
+
+var (
+	aggregatedClient = &config.Client{Aggregation: AggregationIssueDay}
+	separateClient   = &config.Client{}
+	localCalendar    = period.Calendar{Location: time.Local, WeekStart: time.Monday}
+)
+
+func newWorklog(id, clientID string, client *config.Client, issueID, comment string, started time.Time, seconds int) Worklog {
+	return Worklog{
+		ClientID:         clientID,
+		Client:           client,
+		IssueID:          issueID,
+		Comment:          comment,
+		Started:          started,
+		TimeSpentSeconds: seconds,
+		TimeEntries: []clockify.TimeEntry{
+			{ID: id, Description: issueID + " " + comment},
+		},
+	}
+}
+
+func TestAggregateByIssueAndDay(t *testing.T) {
+
+	day1 := time.Date(2025, time.January, 8, 10, 0, 0, 0, time.Local)
+	day2 := time.Date(2025, time.January, 9, 10, 0, 0, 0, time.Local)
+
+	t.Run("Aggregate worklogs of the same issue and day", func(t *testing.T) {
+		worklogs := []Worklog{
+			newWorklog("id1", "client1", aggregatedClient, "ABC-1", "fix", day1.Add(2*time.Hour), 180),
+			newWorklog("id2", "client1", aggregatedClient, "ABC-1", "review", day1, 180),
+			newWorklog("id3", "client1", aggregatedClient, "ABC-1", "fix", day1.Add(4*time.Hour), 180),
+			newWorklog("id4", "client1", aggregatedClient, "ABC-2", "other", day1, 180),
+			newWorklog("id5", "client1", aggregatedClient, "ABC-1", "next day", day2, 180),
+		}
+
+		got := AggregateByIssueAndDay(worklogs, localCalendar)
+
+		assert.Ints(t, len(got), 3)
+		assert.Strings(t, got[0].IssueID, "ABC-1")
+		assert.Ints(t, got[0].TimeSpentSeconds, 540)
+		assert.Strings(t, got[0].Started.String(), day1.String())
+		assert.Strings(t, got[0].Comment, "fix; review\n(clockify: id1, id2, id3)")
+		assert.StringSlices(t, got[0].TimeEntryIDs(), []string{"id1", "id2", "id3"})
+
+		assert.Strings(t, got[1].IssueID, "ABC-2")
+		assert.Strings(t, got[1].Comment, "other")
+		assert.Strings(t, got[2].Comment, "next day")
+		assert.Ints(t, got[2].TimeSpentSeconds, 180)
+	})
+
+	t.Run("Keep worklogs of clients without aggregation", func(t *testing.T) {
+		worklogs := []Worklog{
+			newWorklog("id1", "client1", separateClient, "ABC-1", "fix", day1, 180),
+			newWorklog("id2", "client1", separateClient, "ABC-1", "fix", day1, 180),
+		}
+
+		got := AggregateByIssueAndDay(worklogs, localCalendar)
+
+		assert.Ints(t, len(got), 2)
+		assert.Strings(t, got[0].Comment, "fix")
+	})
+
+	t.Run("Do not aggregate worklogs of different clients", func(t *testing.T) {
+		worklogs := []Worklog{
+			newWorklog("id1", "client1", aggregatedClient, "ABC-1", "", day1, 180),
+			newWorklog("id2", "client2", aggregatedClient, "ABC-1", "", day1, 180),
+			newWorklog("id3", "client2", aggregatedClient, "ABC-1", "", day1, 180),
+		}
+
+		got := AggregateByIssueAndDay(worklogs, localCalendar)
+
+		assert.Ints(t, len(got), 2)
+		assert.Strings(t, got[1].Comment, "(clockify: id2, id3)")
+	})
+}
+
+func TestDescription(t *testing.T) {
+	worklog := Worklog{
+		TimeEntries: []clockify.TimeEntry{
+			{ID: "id1", Description: "ABC-1 fix"},
+			{ID: "id2", Description: "ABC-1 review"},
+		},
+	}
+
+	assert.Strings(t, worklog.Description(), "ABC-1 fix | ABC-1 review")
+}
@@ -1,31 +1,25 @@
 package main
 
 import (
+	"cmp"
 	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
 	"slices"
-	s "strings"
+	"strings"
 	"time"
 
-	"github.com/andygrunwald/go-jira"
-
 	"github.com/kruc/clockify-to-jira/internal/clockify"
 	"github.com/kruc/clockify-to-jira/internal/config"
+	"github.com/kruc/clockify-to-jira/internal/failures"
 	"github.com/kruc/clockify-to-jira/internal/flag"
 	"github.com/kruc/clockify-to-jira/internal/logger"
 	"github.com/kruc/clockify-to-jira/internal/outcome"
+	"github.com/kruc/clockify-to-jira/internal/review"
 	"github.com/kruc/clockify-to-jira/internal/version"
 )
 
-type clockifyData struct {
-	client           string
-	project          string
-	issueID          string
-	issueComment     string
-	started          time.Time
-	timeSpentSeconds int
-}
-
 func main() {
 
 	log := logger.InitializeLogger()
@@ -36,33 +30,93 @@ func main() {
 			"error", err,
 			"args", os.Args,
 		)
-		return
+		os.Exit(outcome.ExitConfigError)
 	}
 
+	runResult := outcome.NewRunResult(flag.Command, flag.Apply, time.Now())
+	runResult.ExitCode = run(log, flag, runResult)
+
+	if flag.ResultFile != "" {
+		if err := runResult.Save(flag.ResultFile); err != nil {
+			log.Error("Ops, something went wrong during run result saving!",
+				"error", err,
+				"file", flag.ResultFile)
+		}
+	}
+
+	os.Exit(runResult.ExitCode)
+}
+
+// run executes the command and returns process exit code
+func run(log *slog.Logger, flag flag.Flag, runResult *outcome.RunResult) int {
+
 	if flag.Help {
 		flag.PrintDefaults()
-		return
+		return outcome.ExitOk
 	}
 
 	if flag.Version {
 		version.ShowBuildDetails(os.Stdout)
-		return
+		return outcome.ExitOk
+	}
+
+	if flag.IsConfigValidate() {
+		return validateConfig(log, flag.ConfigFilePath)
+	}
+
+	if flag.IsConfigInit() {
+		return initializeConfig(log, flag.ConfigFilePath, flag.Interactive)
+	}
+
+	if flag.IsConfigMigrate() {
+		return migrateConfig(log, flag.ConfigFilePath, flag.Write)
+	}
+
+	if flag.IsConfigSchema() {
+		return showConfigSchema(log)
 	}
 
-	config, err := config.LoadFromYamlFile(flag.ConfigFilePath)
+	if flag.IsConfigShow() && !flag.Resolved {
+		return showConfig(log, flag.ConfigFilePath)
+	}
+
+	config, err := config.LoadFromFile(flag.ConfigFilePath)
 
 	if err != nil {
 		log.Error("Ops, something went wrong while loading the configuration!",
 			"error", err)
-		return
+		runResult.Error = err.Error()
+		return outcome.ExitConfigError
 	}
 
-	if flag.Period != 0 {
+	if config.SourceVersion < config.Version {
+		log.Warn("Configuration uses old layout - it was migrated in memory",
+			"solution", "run clockify-to-jira config migrate --write",
+			"version", config.SourceVersion)
+	}
+
+	// flag defaults are used only for settings missing in configuration
+	if flag.IsSet("period") || config.Global.Period == 0 {
 		config.OverwritePeriodSetting(flag.Period)
 	}
 
-	if flag.Precision != 0 {
+	if flag.From != "" {
+		err = config.OverwriteDateRange(flag.From, cmp.Or(flag.To, "now"), time.Now())
+
+		if err != nil {
+			log.Error("Ops, something went wrong while parsing the date range!",
+				"error", err,
+				"from", flag.From,
+				"to", flag.To)
+			runResult.Error = err.Error()
+			return outcome.ExitConfigError
+		}
+	}
+
+	if flag.IsSet("tryb-niepokorny") {
 		config.OverwritePrecisionSetting(flag.Precision)
+	} else {
+		config.SetDefaultPrecision(flag.Precision)
 	}
 
 	workspaces, err := config.FindWorkspaces(flag.Workspaces)
@@ -70,180 +124,161 @@ func main() {
 	if err != nil {
 		log.Error("Ops, something went wrong during workspace listing!",
 			"error", err)
-		return
+		runResult.Error = err.Error()
+		return outcome.ExitConfigError
 	}
 
-	clockifyClient, err := clockify.NewClient(config.Global.ClockifyToken)
+	if flag.IsConfigShow() {
+		fmt.Print(config.ShowResolved())
+		return outcome.ExitOk
+	}
+
+	// only selected workspaces are left in config, so secrets of other clients are not requested
+	err = config.ResolveSecrets()
 
 	if err != nil {
+		log.Error("Ops, something went wrong while resolving secrets!",
+			"error", err)
+		runResult.Error = err.Error()
+		return outcome.ExitConfigError
+	}
+
+	clockifyClient, err := clockify.NewClient(config.Global.ClockifyToken)
+
+	// doctor reports it as failed token check
+	if err != nil && !flag.IsDoctor() {
 		log.Error("Ops, something went wrong during clockify client initialization!",
 			"error", err)
+		runResult.Error = err.Error()
+		return outcome.ExitConfigError
 	}
 
-	ch := make(chan string)
+	failureStore, err := failures.Load(filepath.Join(filepath.Dir(flag.ConfigFilePath), failures.FileName))
 
-	for workspaceKey, workspace := range workspaces {
+	if err != nil {
+		log.Error("Ops, something went wrong during migration failures loading!",
+			"error", err)
+		runResult.Error = err.Error()
+		return outcome.ExitFailure
+	}
+
+	migration := migration{
+		log:            log,
+		flag:           flag,
+		config:         config,
+		clockifyClient: clockifyClient,
+		failures:       failureStore,
+		result:         runResult,
+	}
 
-		go func(chan string) {
+	if flag.IsDoctor() {
+		report := migration.getDoctorReport(workspaces)
+		output, err := report.GetReport(flag.Format)
 
-			clockifyTags, err := clockifyClient.GetWorkspaceTags(workspace.WorkspaceId)
+		if err != nil {
+			log.Error("Ops, something went wrong during doctor report rendering!",
+				"error", err)
+			return outcome.ExitFailure
+		}
 
-			if err != nil {
-				log.Error("Ops, something went wrong during tags fetching!",
-					"error", err)
-			}
+		fmt.Print(output)
+
+		if report.HasFailures() {
+			log.Error("Doctor found problems - see failed checks",
+				"failed", report.Failed)
+			return outcome.ExitFailure
+		}
+		return outcome.ExitOk
+	}
+
+	if flag.IsStatus() {
+		log.Info(migration.getStatusReport(workspaces))
+		return getReportExitCode(runResult)
+	}
+
+	type plannedWorkspace struct {
+		workspaceKey string
+		plan         *workspacePlan
+		err          error
+	}
 
-			now := time.Now()
-			start, end := config.GetTimeInterval(&now)
-
-			timeEntries, err := clockifyClient.GetTimeEntriesFromGivenPeriod(start, end, workspace.WorkspaceId)
-
-			if err != nil {
-				log.Error("Ops, something went wrong during time entries fetching!",
-					"error", err)
-				return
-			}
-
-			summaryData := outcome.SummaryData{Start: start, End: end, Workspace: workspaceKey}
-
-			slices.Reverse(timeEntries)
-
-			for _, timeEntry := range timeEntries {
-
-				if (timeEntry.IsTaggedWith(workspace.JiraMigrationSuccessTag) ||
-					timeEntry.IsTaggedWith(workspace.JiraMigrationSkipTag) ||
-					timeEntry.Duration == "") &&
-					!flag.Debug {
-
-					continue
-				}
-
-				if timeEntry.ProjectID == "" {
-					log.Error("Ops, project not assign to time entry!",
-						"solution", "Edit time entry in clockify and assign it to project",
-						"timeEntry", timeEntry.Description,
-					)
-					continue
-				}
-
-				clientConfigId := s.ToLower(timeEntry.ClientName)
-
-				if len(flag.Clients) != 0 && !slices.Contains(flag.Clients, clientConfigId) {
-					continue
-				}
-
-				clientConfig, err := workspace.GetClient(clientConfigId)
-
-				if err != nil {
-					log.Error("Ops, something went wrong during get client!",
-						"error", err)
-					continue
-				}
-
-				if !clientConfig.Enabled {
-					log.Warn("Don't forget to enable client",
-						"solution", fmt.Sprintf("set workspaces.%s.clients.%s.enabled to true", workspaceKey, clientConfigId),
-					)
-					continue
-				}
-
-				timeDiff := getTimeDiff(timeEntry.Start, *timeEntry.End)
-				timeSpentSeconds, originalTime, roundedTime := dosko(timeDiff, clientConfig.StachurskyMode)
-
-				summaryData.IncreaseTimeEntryCount()
-				summaryData.AddTimeEntryDuration(timeDiff)
-				summaryData.AddDoskoTimeEntryDuration(timeSpentSeconds)
-				summaryData.AddDoskoFactor(clientConfig.StachurskyMode)
-
-				// JIRA PART
-				clockifyData := clockifyData{
-					client:           s.ToLower(timeEntry.ClientName),
-					project:          s.ToLower(timeEntry.ProjectName),
-					issueID:          parseIssueID(timeEntry.Description),
-					issueComment:     parseIssueComment(timeEntry.Description),
-					started:          adjustClockifyDate(timeEntry.Start),
-					timeSpentSeconds: timeSpentSeconds,
-				}
-
-				tp := jira.BasicAuthTransport{
-					Username: clientConfig.JiraUsername,
-					Password: clientConfig.JiraPassword,
-				}
-
-				jiraClient, _ := jira.NewClient(tp.Client(), clientConfig.JiraHost)
-
-				tt := jira.Time(clockifyData.started)
-				worklogRecord := jira.WorklogRecord{
-					Comment:          clockifyData.issueComment,
-					TimeSpentSeconds: clockifyData.timeSpentSeconds,
-					Started:          &tt,
-				}
-
-				if flag.Apply {
-
-					jwr, jr, err := jiraClient.Issue.AddWorklogRecord(clockifyData.issueID, &worklogRecord)
-
-					if err != nil {
-						log.Error("Ops, something went wrong during worklog record adding!",
-							"error", err,
-							"worklogRecord", jwr,
-							"response", jr,
-						)
-
-						timeEntry.AddTag(clockifyTags[workspace.JiraMigrationFailedTag])
-						log.Info(fmt.Sprintf("Add %v tag", workspace.JiraMigrationFailedTag))
-					} else {
-						log.Info("Jira workload added")
-						timeEntry.RemoveTag(workspace.JiraMigrationFailedTag)
-						timeEntry.AddTag(clockifyTags[workspace.JiraMigrationSuccessTag])
-						log.Info(fmt.Sprintf("Add %v tag", workspace.JiraMigrationSuccessTag))
-					}
-
-					te, err := clockifyClient.UpdateTimeEntry(workspace.WorkspaceId, timeEntry)
-
-					if err != nil {
-						log.Error("Ops, something went wrong during time entry updating",
-							"error", err,
-							"timeEntry", te,
-						)
-					}
-
-					issueURL := fmt.Sprintf("%v/browse/%v?focusedWorklogId=%s", clientConfig.JiraHost, clockifyData.issueID, "123")
-					log.Info("Finish timentry processing",
-						"Id", timeEntry.ID,
-						"Description", timeEntry.Description,
-						"IssueUrl", issueURL)
-				}
-
-				worklogData := outcome.WorklogData{
-					Description: timeEntry.Description,
-					Workspace:   workspaceKey,
-					Client:      clockifyData.client,
-					Project:     clockifyData.project,
-					Date:        clockifyData.started,
-					Comment:     worklogRecord.Comment,
-					Tags:        timeEntry.GetTagNamesList(),
-					TimeSpent: outcome.DoskoDetails{
-						OriginalTime: originalTime,
-						RoundedTime:  roundedTime,
-						Precision:    clientConfig.StachurskyMode,
-					},
-				}
-
-				worklog := worklogData.GetSummary()
-
-				log.Info(worklog)
-			}
-			summary, err := summaryData.GetSummary()
-			if err != nil {
-				log.Error("Ops, something went wrong during fetching summary!",
-					"error", err)
-			}
-			ch <- summary
+	ch := make(chan plannedWorkspace)
+
+	for workspaceKey, workspace := range workspaces {
+
+		go func(chan plannedWorkspace) {
+			plan, err := migration.planWorkspace(workspaceKey, workspace)
+
+			ch <- plannedWorkspace{workspaceKey: workspaceKey, plan: plan, err: err}
 		}(ch)
 	}
 
+	plans := []*workspacePlan{}
+
 	for i := 0; i < len(workspaces); i++ {
-		log.Info(<-ch)
+		planned := <-ch
+
+		if planned.err != nil {
+			log.Error("Ops, something went wrong during time entries fetching!",
+				"error", planned.err,
+				"workspace", planned.workspaceKey)
+			runResult.WorkspaceFailed(planned.workspaceKey, planned.err)
+			continue
+		}
+
+		plans = append(plans, planned.plan)
+	}
+
+	slices.SortFunc(plans, func(a, b *workspacePlan) int {
+		return strings.Compare(a.workspaceKey, b.workspaceKey)
+	})
+
+	if flag.IsDriftReport() {
+		log.Info(migration.getDriftReport(plans))
+		return getReportExitCode(runResult)
+	}
+
+	migration.warnMissingEntries(plans)
+	migration.applyCaps(plans)
+
+	if flag.Interactive {
+		confirmed, err := migration.reviewPlans(plans, review.New(os.Stdin, os.Stdout, config.Calendar().Location))
+
+		if err != nil {
+			log.Error("Ops, something went wrong during worklogs review!",
+				"error", err)
+			runResult.Error = err.Error()
+			return outcome.ExitFailure
+		}
+
+		if !confirmed {
+			log.Info("Nothing was applied")
+			return outcome.ExitNothingToDo
+		}
+	}
+
+	for _, plan := range plans {
+		log.Info(migration.executeWorkspacePlan(plan))
 	}
+
+	if flag.Apply {
+		if err := failureStore.Save(); err != nil {
+			log.Error("Ops, something went wrong during migration failures saving!",
+				"error", err)
+		}
+	}
+
+	return runResult.GetExitCode()
+}
+
+// getReportExitCode fails reports missing any workspace, reports with no time entries are not treated as nothing to do
+func getReportExitCode(runResult *outcome.RunResult) int {
+
+	for _, workspaceResult := range runResult.Workspaces {
+		if workspaceResult.Error != "" {
+			return outcome.ExitFailure
+		}
+	}
+
+	return outcome.ExitOk
 }
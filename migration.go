@@ -0,0 +1,625 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	s "strings"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/clockify"
+	"github.com/kruc/clockify-to-jira/internal/config"
+	"github.com/kruc/clockify-to-jira/internal/failures"
+	"github.com/kruc/clockify-to-jira/internal/flag"
+	"github.com/kruc/clockify-to-jira/internal/jira"
+	"github.com/kruc/clockify-to-jira/internal/outcome"
+	"github.com/kruc/clockify-to-jira/internal/worklog"
+)
+
+type migration struct {
+	log            *slog.Logger
+	flag           flag.Flag
+	config         config.Config
+	clockifyClient *clockify.ApiClient
+	failures       *failures.Store
+	result         *outcome.RunResult
+}
+
+type workspacePlan struct {
+	workspaceKey    string
+	workspace       *config.Workspace
+	clockifyTags    map[string]clockify.Tag
+	summaryData     outcome.SummaryData
+	worklogs        []worklog.Worklog
+	ignoredWorklogs []worklog.Worklog
+	waitingWorklogs []worklog.Worklog
+	loggedWorklogs  []worklog.Worklog
+	missingEntries  []string
+	// clients of time entries and worklogs rejected during planning, one per rejection
+	rejectedClients []string
+}
+
+func (m *migration) planWorkspace(workspaceKey string, workspace *config.Workspace) (*workspacePlan, error) {
+
+	clockifyTags, err := m.clockifyClient.GetWorkspaceTags(workspace.WorkspaceId)
+
+	if err != nil {
+		m.log.Error("Ops, something went wrong during tags fetching!",
+			"error", err)
+	}
+
+	now := time.Now()
+	start, end := m.config.GetTimeInterval(&now)
+
+	timeEntries, missingEntries, err := m.fetchTimeEntries(start, end, workspace, clockifyTags)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if (len(m.flag.Entries) != 0 || m.flag.RetryFailed) && len(timeEntries) != 0 {
+		start, end = timeEntries[0].Start, timeEntries[len(timeEntries)-1].Start
+
+		for _, timeEntry := range timeEntries {
+			if timeEntry.End != nil && timeEntry.End.After(end) {
+				end = *timeEntry.End
+			}
+		}
+	}
+
+	worklogs, rejectedClients := m.planWorklogs(workspaceKey, workspace, timeEntries)
+	worklogs = worklog.MergeAdjacent(worklogs)
+	worklogs, ignoredWorklogs, waitingWorklogs := worklog.ApplyMinDuration(worklogs)
+	worklogs = worklog.AggregateByIssueAndDay(worklogs, m.config.Calendar())
+	worklogs, roundingRejectedClients := m.roundWorklogs(worklogs)
+
+	return &workspacePlan{
+		workspaceKey:    workspaceKey,
+		workspace:       workspace,
+		clockifyTags:    clockifyTags,
+		summaryData:     outcome.SummaryData{Start: start, End: end, Workspace: workspaceKey},
+		worklogs:        worklogs,
+		ignoredWorklogs: ignoredWorklogs,
+		waitingWorklogs: waitingWorklogs,
+		missingEntries:  missingEntries,
+		rejectedClients: slices.Concat(rejectedClients, roundingRejectedClients),
+	}, nil
+}
+
+// fetchTimeEntries returns time entries given with --entry flag, failed time entries (--retry-failed) or time entries of the period, oldest first
+func (m *migration) fetchTimeEntries(start, end time.Time, workspace *config.Workspace, clockifyTags map[string]clockify.Tag) ([]clockify.TimeEntry, []string, error) {
+
+	var (
+		timeEntries []clockify.TimeEntry
+		err         error
+	)
+
+	switch {
+	case len(m.flag.Entries) != 0:
+		return m.clockifyClient.GetTimeEntriesByIds(workspace.WorkspaceId, m.flag.Entries)
+	case m.flag.RetryFailed:
+		failedTag, ok := clockifyTags[workspace.JiraMigrationFailedTag]
+
+		if !ok {
+			return []clockify.TimeEntry{}, nil, nil
+		}
+
+		timeEntries, err = m.clockifyClient.GetTaggedTimeEntries(start, end, workspace.WorkspaceId, failedTag.ID)
+	default:
+		timeEntries, err = m.clockifyClient.GetTimeEntriesFromGivenPeriod(start, end, workspace.WorkspaceId)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slices.Reverse(timeEntries)
+
+	return timeEntries, nil, nil
+}
+
+// warnMissingEntries lists --entry ids which weren't found in any of selected workspaces
+func (m *migration) warnMissingEntries(plans []*workspacePlan) {
+
+	for _, entryId := range m.flag.Entries {
+		missing := len(plans) != 0
+
+		for _, plan := range plans {
+			missing = missing && slices.Contains(plan.missingEntries, entryId)
+		}
+
+		if missing {
+			m.log.Warn("Time entry not found in selected workspaces",
+				"solution", "check time entry id, workspace (-w) flag and whether the time entry is yours",
+				"timeEntry", entryId,
+			)
+		}
+	}
+}
+
+func (m *migration) applyCaps(plans []*workspacePlan) {
+
+	worklogs := []worklog.Worklog{}
+
+	for _, plan := range plans {
+		worklogs = append(worklogs, plan.worklogs...)
+	}
+
+	if !hasCaps(worklogs, m.globalCap()) {
+		return
+	}
+
+	start, end := worklog.CapWindow(worklogs, m.config.Calendar())
+	loggedWorklogs := []worklog.Worklog{}
+
+	for _, plan := range plans {
+		plan.loggedWorklogs = m.getLoggedWorklogs(plan, start, end)
+		loggedWorklogs = append(loggedWorklogs, plan.loggedWorklogs...)
+	}
+
+	m.checkCaps(worklogs, loggedWorklogs)
+
+	for _, plan := range plans {
+		plan.worklogs, worklogs = worklogs[:len(plan.worklogs)], worklogs[len(plan.worklogs):]
+	}
+}
+
+// checkCaps blocks or scales worklogs exceeding caps and displays caps summary
+func (m *migration) checkCaps(worklogs, loggedWorklogs []worklog.Worklog) {
+
+	checks := worklog.ApplyCaps(worklogs, loggedWorklogs, m.globalCap(), m.config.Calendar())
+
+	if len(checks) == 0 {
+		return
+	}
+
+	capsData := []outcome.CapData{}
+
+	for _, check := range checks {
+		if check.Exceeded() {
+			m.log.Warn("Time cap exceeded",
+				"scope", check.Scope,
+				"period", check.Period,
+				"action", check.Action,
+			)
+		}
+
+		capsData = append(capsData, outcome.CapData(check))
+	}
+
+	capsSummary, err := outcome.GetCapsSummary(capsData)
+
+	if err != nil {
+		m.log.Error("Ops, something went wrong during fetching caps summary!",
+			"error", err)
+		return
+	}
+
+	m.log.Info(capsSummary)
+}
+
+// getLoggedWorklogs plans time entries of the cap window migrated in previous runs, caps count their rounded time
+func (m *migration) getLoggedWorklogs(plan *workspacePlan, start, end time.Time) []worklog.Worklog {
+
+	successTag, ok := plan.clockifyTags[plan.workspace.JiraMigrationSuccessTag]
+
+	if !ok {
+		return nil
+	}
+
+	timeEntries, err := m.clockifyClient.GetTaggedTimeEntries(start, end, plan.workspace.WorkspaceId, successTag.ID)
+
+	if err != nil {
+		m.log.Warn("Ops, something went wrong during logged time entries fetching - caps count time of this run only!",
+			"error", err,
+			"workspace", plan.workspaceKey)
+		return nil
+	}
+
+	// client problems were reported when the time entries were migrated
+	quiet := *m
+	quiet.log = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	worklogs := []worklog.Worklog{}
+
+	for _, timeEntry := range timeEntries {
+
+		if timeEntry.End == nil || timeEntry.ProjectID == "" || slices.ContainsFunc(plan.worklogs, func(planned worklog.Worklog) bool {
+			return slices.Contains(planned.TimeEntryIDs(), timeEntry.ID)
+		}) {
+			continue
+		}
+
+		clientConfigId, clientConfig, clientRule, err := quiet.resolveClient(plan.workspaceKey, plan.workspace, timeEntry)
+
+		if err != nil || clientConfig == nil {
+			continue
+		}
+
+		worklogs = append(worklogs, newWorklog(plan.workspaceKey, clientConfigId, clientConfig, clientRule, timeEntry))
+	}
+
+	worklogs = worklog.MergeAdjacent(worklogs)
+	worklogs = worklog.AggregateByIssueAndDay(worklogs, m.config.Calendar())
+
+	worklogs, _ = quiet.roundWorklogs(worklogs)
+
+	return worklogs
+}
+
+func (m *migration) globalCap() worklog.Cap {
+	return worklog.Cap{
+		Daily:  m.config.Global.DailyCap,
+		Weekly: m.config.Global.WeeklyCap,
+		Action: m.config.Global.CapAction,
+	}
+}
+
+func hasCaps(worklogs []worklog.Worklog, globalCap worklog.Cap) bool {
+
+	if globalCap.Daily > 0 || globalCap.Weekly > 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(worklogs, func(w worklog.Worklog) bool {
+		return w.Client.DailyCap > 0 || w.Client.WeeklyCap > 0
+	})
+}
+
+func (m *migration) executeWorkspacePlan(plan *workspacePlan) string {
+
+	for _, clientId := range plan.rejectedClients {
+		m.result.Add(plan.workspaceKey, clientId, outcome.ResultFailed)
+	}
+
+	for _, ignoredWorklog := range plan.ignoredWorklogs {
+
+		for _, timeEntry := range ignoredWorklog.TimeEntries {
+			plan.summaryData.AddIgnoredTimeEntry(timeEntry.Description, getTimeDiff(timeEntry.Start, *timeEntry.End))
+		}
+
+		if m.flag.Apply {
+			m.skipWorklog(plan.workspace, plan.clockifyTags, &ignoredWorklog)
+		}
+
+		m.result.Add(plan.workspaceKey, ignoredWorklog.ClientID, outcome.ResultSkipped)
+	}
+
+	// not tagged - next run merges them into the following time entry of the issue
+	for _, waitingWorklog := range plan.waitingWorklogs {
+		m.log.Info("Worklog shorter than min_duration waits for next time entry of the issue to merge",
+			"issueID", waitingWorklog.IssueID,
+			"Description", waitingWorklog.Description(),
+		)
+	}
+
+	for _, plannedWorklog := range plan.worklogs {
+
+		roundingOptions := getRoundingOptions(plannedWorklog.Client)
+		strategyDurations, _ := compareRoundingStrategies(plannedWorklog.TimeSpentSeconds, roundingOptions)
+
+		for range plannedWorklog.TimeEntries {
+			plan.summaryData.IncreaseTimeEntryCount()
+		}
+
+		plan.summaryData.AddTimeEntryDuration(plannedWorklog.TimeSpentSeconds)
+		plan.summaryData.AddDoskoTimeEntryDuration(plannedWorklog.RoundedSeconds)
+		plan.summaryData.AddDoskoFactor(plannedWorklog.Client.StachurskyMode)
+		plan.summaryData.AddStrategyDurations(strategyDurations)
+
+		switch {
+		case m.flag.Apply && plannedWorklog.Blocked:
+			m.log.Warn("Worklog blocked by time cap - it will be migrated on next run",
+				"issueID", plannedWorklog.IssueID,
+				"Description", plannedWorklog.Description(),
+			)
+			m.result.Add(plan.workspaceKey, plannedWorklog.ClientID, outcome.ResultBlocked)
+		case m.flag.Apply && m.applyWorklog(plan.workspace, plan.clockifyTags, &plannedWorklog):
+			m.result.Add(plan.workspaceKey, plannedWorklog.ClientID, outcome.ResultLogged)
+		case m.flag.Apply:
+			m.result.Add(plan.workspaceKey, plannedWorklog.ClientID, outcome.ResultFailed)
+		default:
+			m.result.Add(plan.workspaceKey, plannedWorklog.ClientID, outcome.ResultPlanned)
+		}
+
+		worklogData := outcome.WorklogData{
+			Description: plannedWorklog.Description(),
+			Workspace:   plan.workspaceKey,
+			Client:      plannedWorklog.ClientID,
+			ClientRule:  plannedWorklog.ClientRule,
+			Project:     plannedWorklog.Project,
+			Date:        plannedWorklog.Started,
+			Comment:     plannedWorklog.Comment,
+			Tags:        plannedWorklog.TagNames(),
+			TimeSpent: outcome.DoskoDetails{
+				OriginalTime: formatSeconds(plannedWorklog.TimeSpentSeconds),
+				RoundedTime:  formatSeconds(plannedWorklog.RoundedSeconds),
+				Precision:    plannedWorklog.Client.StachurskyMode,
+				Strategy:     roundingOptions.Strategy,
+			},
+		}
+
+		m.log.Info(worklogData.GetSummary())
+	}
+
+	summary, err := plan.summaryData.GetSummary()
+
+	if err != nil {
+		m.log.Error("Ops, something went wrong during fetching summary!",
+			"error", err)
+	}
+
+	return summary
+}
+
+// roundWorklogs rounds time spent of worklogs, returns clients of worklogs which cannot be rounded as well
+func (m *migration) roundWorklogs(worklogs []worklog.Worklog) ([]worklog.Worklog, []string) {
+
+	rounded := []worklog.Worklog{}
+	rejectedClients := []string{}
+
+	for _, plannedWorklog := range worklogs {
+
+		roundingOptions := getRoundingOptions(plannedWorklog.Client)
+		timeSpentSeconds, _, _, err := dosko(plannedWorklog.TimeSpentSeconds, roundingOptions)
+
+		if err != nil {
+			m.log.Error("Ops, something went wrong during time rounding!",
+				"error", err,
+				"solution", fmt.Sprintf("check rounding settings of workspaces.%s.clients.%s", plannedWorklog.Workspace, plannedWorklog.ClientID),
+			)
+			rejectedClients = append(rejectedClients, plannedWorklog.ClientID)
+			continue
+		}
+
+		plannedWorklog.RoundedSeconds = timeSpentSeconds
+		rounded = append(rounded, plannedWorklog)
+	}
+
+	return rounded, rejectedClients
+}
+
+// planWorklogs plans worklog of every time entry to migrate, returns clients of rejected time entries as well
+func (m *migration) planWorklogs(workspaceKey string, workspace *config.Workspace, timeEntries []clockify.TimeEntry) ([]worklog.Worklog, []string) {
+
+	worklogs := []worklog.Worklog{}
+	rejectedClients := []string{}
+
+	for _, timeEntry := range timeEntries {
+
+		// drift report covers already logged time entries as well
+		logged := timeEntry.IsTaggedWith(workspace.JiraMigrationSuccessTag) && !m.flag.IsDriftReport()
+
+		if (logged ||
+			timeEntry.IsTaggedWith(workspace.JiraMigrationSkipTag) ||
+			timeEntry.Duration == "") &&
+			!m.flag.Debug {
+
+			continue
+		}
+
+		if !matchesIssue(timeEntry.Description, m.flag.Issues) {
+			continue
+		}
+
+		if timeEntry.IsTaggedWith(workspace.JiraMigrationFailedTag) && !m.shouldRetry(timeEntry) {
+			continue
+		}
+
+		if timeEntry.ProjectID == "" {
+			m.log.Error("Ops, project not assign to time entry!",
+				"solution", "Edit time entry in clockify and assign it to project",
+				"timeEntry", timeEntry.Description,
+			)
+			rejectedClients = append(rejectedClients, unknownClient)
+			continue
+		}
+
+		clientConfigId, clientConfig, clientRule, err := m.resolveClient(workspaceKey, workspace, timeEntry)
+
+		if err != nil {
+			rejectedClients = append(rejectedClients, cmp.Or(timeEntry.ClientName, unknownClient))
+			continue
+		}
+
+		// filtered out or disabled client
+		if clientConfig == nil {
+			continue
+		}
+
+		worklogs = append(worklogs, newWorklog(workspaceKey, clientConfigId, clientConfig, clientRule, timeEntry))
+	}
+
+	return worklogs, rejectedClients
+}
+
+func newWorklog(workspaceKey, clientConfigId string, clientConfig *config.Client, clientRule string, timeEntry clockify.TimeEntry) worklog.Worklog {
+	return worklog.Worklog{
+		Workspace:        workspaceKey,
+		ClientID:         clientConfigId,
+		Client:           clientConfig,
+		ClientRule:       clientRule,
+		Project:          s.ToLower(timeEntry.ProjectName),
+		IssueID:          parseIssueID(timeEntry.Description),
+		Comment:          parseIssueComment(timeEntry.Description),
+		Started:          adjustClockifyDate(timeEntry.Start),
+		TimeSpentSeconds: getTimeDiff(timeEntry.Start, *timeEntry.End),
+		TimeEntries:      []clockify.TimeEntry{timeEntry},
+	}
+}
+
+// shouldRetry explains previous failure of time entry, --retry-failed stops retrying after retry limit.
+// Other runs migrate failed time entries regardless of the limit
+func (m *migration) shouldRetry(timeEntry clockify.TimeEntry) bool {
+
+	failure, ok := m.failures.Get(timeEntry.ID)
+
+	if !ok {
+		failure.Reason = "unknown - failed before failures were recorded"
+	}
+
+	if failure.Attempts >= m.config.GetRetryLimit() && m.flag.RetryFailed {
+		m.log.Warn("Retry limit reached - time entry is not migrated anymore",
+			"solution", "fix the reason and migrate it without --retry-failed flag or tag it with jira_migration_skip_tag",
+			"timeEntry", timeEntry.Description,
+			"id", timeEntry.ID,
+			"attempts", failure.Attempts,
+			"reason", failure.Reason,
+		)
+		return false
+	}
+
+	if m.flag.RetryFailed {
+		m.log.Info("Retrying failed time entry",
+			"timeEntry", timeEntry.Description,
+			"id", timeEntry.ID,
+			"attempt", fmt.Sprintf("%d/%d", failure.Attempts+1, m.config.GetRetryLimit()),
+			"reason", failure.Reason,
+		)
+	}
+
+	return true
+}
+
+func (m *migration) resolveClient(workspaceKey string, workspace *config.Workspace, timeEntry clockify.TimeEntry) (string, *config.Client, string, error) {
+
+	clientConfigId, clientConfig, clientRule, err := workspace.GetClient(timeEntry.ClientID, timeEntry.ClientName)
+
+	if jiraHost, _, ok := parseIssueURL(timeEntry.Description); ok {
+		hostClientId, hostClientConfig, hostErr := workspace.FindClientByJiraHost(jiraHost, clientConfigId)
+
+		if hostErr != nil {
+			m.log.Error("Ops, something went wrong during get client by jira host!",
+				"error", hostErr,
+				"jiraHost", jiraHost,
+				"timeEntry", timeEntry.Description,
+			)
+			return "", nil, "", hostErr
+		}
+
+		if hostClientId != clientConfigId {
+			m.log.Warn("Time entry client doesn't match jira host from issue url",
+				"solution", fmt.Sprintf("Change time entry client in clockify to match %s", hostClientId),
+				"timeEntry", timeEntry.Description,
+				"clockifyClient", timeEntry.ClientName,
+				"jiraHostClient", hostClientId,
+			)
+			clientRule = config.ClientMatchJiraHost
+		}
+
+		clientConfigId, clientConfig, err = hostClientId, hostClientConfig, nil
+	}
+
+	if len(m.flag.Clients) != 0 && !slices.Contains(m.flag.Clients, clientConfigId) {
+		return "", nil, "", nil
+	}
+
+	if err != nil {
+		m.log.Error("Ops, something went wrong during get client!",
+			"error", err,
+			"solution", fmt.Sprintf("add clockify_client_id or aliases to client in workspaces.%s.clients", workspaceKey),
+			"clockifyClient", timeEntry.ClientName,
+			"clockifyClientId", timeEntry.ClientID,
+		)
+		return "", nil, "", err
+	}
+
+	if !clientConfig.Enabled {
+		m.log.Warn("Don't forget to enable client",
+			"solution", fmt.Sprintf("set workspaces.%s.clients.%s.enabled to true", workspaceKey, clientConfigId),
+		)
+		return "", nil, "", nil
+	}
+
+	return clientConfigId, clientConfig, clientRule, nil
+}
+
+// applyWorklog adds jira worklog and tags its time entries, it reports whether worklog was migrated completely
+func (m *migration) applyWorklog(workspace *config.Workspace, clockifyTags map[string]clockify.Tag, plannedWorklog *worklog.Worklog) bool {
+
+	jiraClient, err := jira.NewClient(
+		plannedWorklog.Client.JiraHost,
+		plannedWorklog.Client.JiraUsername,
+		plannedWorklog.Client.JiraPassword,
+		plannedWorklog.Client.JiraApiVersion,
+	)
+
+	if err != nil {
+		m.log.Error("Ops, something went wrong during jira client initialization!",
+			"error", err)
+		return false
+	}
+
+	worklogID, err := jiraClient.AddWorklog(plannedWorklog.IssueID, jira.Worklog{
+		Comment:          plannedWorklog.Comment,
+		TimeSpentSeconds: plannedWorklog.RoundedSeconds,
+		Started:          plannedWorklog.Started,
+	})
+
+	if err != nil {
+		m.log.Error("Ops, something went wrong during worklog record adding!",
+			"error", err,
+			"issueID", plannedWorklog.IssueID,
+		)
+	} else {
+		m.log.Info("Jira workload added")
+	}
+
+	migrated := err == nil
+
+	for index := range plannedWorklog.TimeEntries {
+		timeEntry := &plannedWorklog.TimeEntries[index]
+
+		if err != nil {
+			timeEntry.AddTag(clockifyTags[workspace.JiraMigrationFailedTag])
+			m.failures.Record(timeEntry.ID, plannedWorklog.IssueID, err.Error(), time.Now())
+			m.log.Info(fmt.Sprintf("Add %v tag", workspace.JiraMigrationFailedTag))
+		} else {
+			timeEntry.RemoveTag(workspace.JiraMigrationFailedTag)
+			m.failures.Clear(timeEntry.ID)
+			timeEntry.AddTag(clockifyTags[workspace.JiraMigrationSuccessTag])
+			m.log.Info(fmt.Sprintf("Add %v tag", workspace.JiraMigrationSuccessTag))
+		}
+
+		te, updateErr := m.clockifyClient.UpdateTimeEntry(workspace.WorkspaceId, *timeEntry)
+
+		if updateErr != nil {
+			m.log.Error("Ops, something went wrong during time entry updating",
+				"error", updateErr,
+				"timeEntry", te,
+			)
+			migrated = false
+		}
+
+		m.log.Info("Finish timentry processing",
+			"Id", timeEntry.ID,
+			"Description", timeEntry.Description,
+			"IssueUrl", jiraClient.IssueURL(plannedWorklog.IssueID, worklogID))
+	}
+
+	return migrated
+}
+
+func (m *migration) skipWorklog(workspace *config.Workspace, clockifyTags map[string]clockify.Tag, ignoredWorklog *worklog.Worklog) {
+
+	for index := range ignoredWorklog.TimeEntries {
+		timeEntry := &ignoredWorklog.TimeEntries[index]
+
+		timeEntry.AddTag(clockifyTags[workspace.JiraMigrationSkipTag])
+		m.log.Info(fmt.Sprintf("Add %v tag", workspace.JiraMigrationSkipTag),
+			"Id", timeEntry.ID,
+			"Description", timeEntry.Description,
+		)
+
+		te, err := m.clockifyClient.UpdateTimeEntry(workspace.WorkspaceId, *timeEntry)
+
+		if err != nil {
+			m.log.Error("Ops, something went wrong during time entry updating",
+				"error", err,
+				"timeEntry", te,
+			)
+		}
+	}
+}
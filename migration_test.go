@@ -0,0 +1,164 @@
+package main
+
+import (
+	"io"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/clockify"
+	"github.com/kruc/clockify-to-jira/internal/config"
+	"github.com/kruc/clockify-to-jira/internal/failures"
+	"github.com/kruc/clockify-to-jira/internal/flag"
+	"github.com/kruc/clockify-to-jira/internal/outcome"
+	"github.com/kruc/clockify-to-jira/internal/worklog"
+)
+
+func Test_shouldRetry(t *testing.T) {
+	store, _ := failures.Load(filepath.Join(t.TempDir(), failures.FileName))
+	now := time.Date(2025, time.January, 13, 10, 0, 0, 0, time.UTC)
+
+	store.Record("once", "ABC-1", "issue does not exist", now)
+	store.Record("twice", "ABC-1", "issue does not exist", now)
+	store.Record("twice", "ABC-1", "issue does not exist", now)
+
+	tests := []struct {
+		name        string
+		flag        flag.Flag
+		retryLimit  int
+		timeEntryID string
+		want        bool
+	}{
+		{
+			name:        "Retry time entry without recorded failure",
+			timeEntryID: "unknown",
+			want:        true,
+		},
+		{
+			name:        "Retry time entry below default limit",
+			timeEntryID: "twice",
+			want:        true,
+		},
+		{
+			name:        "Stop retrying after configured limit",
+			flag:        flag.Flag{RetryFailed: true},
+			retryLimit:  2,
+			timeEntryID: "twice",
+			want:        false,
+		},
+		{
+			name:        "Retry time entry below configured limit",
+			flag:        flag.Flag{RetryFailed: true},
+			retryLimit:  2,
+			timeEntryID: "once",
+			want:        true,
+		},
+		{
+			name:        "Migrate failed time entry without retry flag regardless of limit",
+			retryLimit:  1,
+			timeEntryID: "twice",
+			want:        true,
+		},
+		{
+			name:        "Migrate time entry given with entry flag regardless of limit",
+			flag:        flag.Flag{Entries: []string{"twice"}},
+			retryLimit:  1,
+			timeEntryID: "twice",
+			want:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &migration{
+				log:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+				flag:     tt.flag,
+				config:   config.Config{Global: config.Global{RetryLimit: tt.retryLimit}},
+				failures: store,
+			}
+
+			if got := m.shouldRetry(clockify.TimeEntry{ID: tt.timeEntryID}); got != tt.want {
+				t.Errorf("shouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_hasCaps(t *testing.T) {
+	worklogs := []worklog.Worklog{
+		{Client: &config.Client{}},
+		{Client: &config.Client{WeeklyCap: 2400}},
+	}
+
+	tests := []struct {
+		name      string
+		worklogs  []worklog.Worklog
+		globalCap worklog.Cap
+		want      bool
+	}{
+		{name: "No caps", worklogs: worklogs[:1], want: false},
+		{name: "Client cap", worklogs: worklogs, want: true},
+		{name: "Global cap", worklogs: worklogs[:1], globalCap: worklog.Cap{Daily: 480}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasCaps(tt.worklogs, tt.globalCap); got != tt.want {
+				t.Errorf("hasCaps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_planWorklogs(t *testing.T) {
+	start := time.Date(2025, time.January, 13, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	workspace := &config.Workspace{
+		Clients: config.Clients{
+			"acme": {Enabled: true},
+		},
+	}
+	newTimeEntry := func(id, description string) clockify.TimeEntry {
+		return clockify.TimeEntry{ID: id, Description: description, ClientName: "acme", ProjectID: "project", Start: start, End: &end, Duration: "PT1H"}
+	}
+
+	t.Run("Return clients of time entries rejected during planning", func(t *testing.T) {
+		m := &migration{log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+		planned := newTimeEntry("planned", "ABC-1 planned")
+		withoutProject := newTimeEntry("no-project", "ABC-2 without project")
+		withoutProject.ProjectID = ""
+		unmapped := newTimeEntry("unmapped", "ABC-3 unmapped client")
+		unmapped.ClientName = "Globex"
+
+		got, rejectedClients := m.planWorklogs("ws_1", workspace, []clockify.TimeEntry{planned, withoutProject, unmapped})
+
+		if len(got) != 1 || got[0].IssueID != "ABC-1" {
+			t.Errorf("planWorklogs() = %v, want single worklog of ABC-1", got)
+		}
+
+		if !slices.Equal(rejectedClients, []string{unknownClient, "Globex"}) {
+			t.Errorf("planWorklogs() rejected = %q, want %q", rejectedClients, []string{unknownClient, "Globex"})
+		}
+	})
+}
+
+func Test_executeWorkspacePlan(t *testing.T) {
+
+	t.Run("Count time entries rejected during planning as failed", func(t *testing.T) {
+		m := &migration{
+			log:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+			result: outcome.NewRunResult([]string{"migrate"}, false, time.Now()),
+		}
+		plan := &workspacePlan{workspaceKey: "ws_1", rejectedClients: []string{"acme", unknownClient}}
+
+		m.executeWorkspacePlan(plan)
+
+		if got := m.result.Workspaces["ws_1"].Clients["acme"].Failed; got != 1 {
+			t.Errorf("executeWorkspacePlan() failed of acme = %d, want 1", got)
+		}
+
+		if got := m.result.GetExitCode(); got != outcome.ExitFailure {
+			t.Errorf("GetExitCode() = %d, want %d", got, outcome.ExitFailure)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/kruc/clockify-to-jira/internal/report"
+)
+
+func (m *migration) getDriftReport(plans []*workspacePlan) string {
+
+	if len(plans) == 0 {
+		return "No time entries to report"
+	}
+
+	driftData := report.NewDriftData(plans[0].summaryData.Start, plans[0].summaryData.End, m.config.Calendar())
+
+	for _, plan := range plans {
+		for _, plannedWorklog := range plan.worklogs {
+			driftData.Add(
+				plannedWorklog.ClientID,
+				plannedWorklog.Project,
+				plannedWorklog.Started,
+				plannedWorklog.TimeSpentSeconds,
+				plannedWorklog.RoundedSeconds,
+			)
+		}
+
+		// time below min_duration is tracked but not logged
+		for _, ignoredWorklog := range slices.Concat(plan.ignoredWorklogs, plan.waitingWorklogs) {
+			driftData.Add(
+				ignoredWorklog.ClientID,
+				ignoredWorklog.Project,
+				ignoredWorklog.Started,
+				ignoredWorklog.TimeSpentSeconds,
+				0,
+			)
+		}
+	}
+
+	return driftData.GetReport()
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"github.com/kruc/clockify-to-jira/internal/review"
+	"github.com/kruc/clockify-to-jira/internal/worklog"
+)
+
+// reviewPlans asks for action of every planned worklog and final confirmation, edited worklogs are checked again before it.
+// Skipped worklogs are removed from plans, tagged ones are moved to ignored worklogs
+func (m *migration) reviewPlans(plans []*workspacePlan, reviewer *review.Review) (bool, error) {
+
+	worklogs := []worklog.Worklog{}
+
+	for _, plan := range plans {
+		worklogs = append(worklogs, plan.worklogs...)
+	}
+
+	actions, err := reviewer.Run(worklogs)
+
+	if err != nil {
+		return false, err
+	}
+
+	m.checkReviewedWorklogs(plans, worklogs, actions)
+
+	confirmed, err := reviewer.Confirm(worklogs, actions)
+
+	if err != nil || !confirmed {
+		return false, err
+	}
+
+	for _, plan := range plans {
+		count := len(plan.worklogs)
+		plan.worklogs = []worklog.Worklog{}
+
+		for index, reviewedWorklog := range worklogs[:count] {
+			switch actions[index] {
+			case review.ActionAccept:
+				plan.worklogs = append(plan.worklogs, reviewedWorklog)
+			case review.ActionSkipAndTag:
+				plan.ignoredWorklogs = append(plan.ignoredWorklogs, reviewedWorklog)
+			}
+		}
+
+		worklogs, actions = worklogs[count:], actions[count:]
+	}
+
+	return true, nil
+}
+
+// checkReviewedWorklogs checks edited durations against caps, caps block or scale accepted worklogs again
+func (m *migration) checkReviewedWorklogs(plans []*workspacePlan, worklogs []worklog.Worklog, actions []string) {
+
+	accepted := []worklog.Worklog{}
+	acceptedIndexes := []int{}
+
+	for index, reviewedWorklog := range worklogs {
+		if actions[index] != review.ActionAccept || reviewedWorklog.Blocked {
+			continue
+		}
+
+		accepted = append(accepted, reviewedWorklog)
+		acceptedIndexes = append(acceptedIndexes, index)
+	}
+
+	if !hasCaps(accepted, m.globalCap()) {
+		return
+	}
+
+	loggedWorklogs := []worklog.Worklog{}
+
+	for _, plan := range plans {
+		loggedWorklogs = append(loggedWorklogs, plan.loggedWorklogs...)
+	}
+
+	m.checkCaps(accepted, loggedWorklogs)
+
+	for index, checkedWorklog := range accepted {
+		worklogs[acceptedIndexes[index]] = checkedWorklog
+	}
+}
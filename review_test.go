@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/config"
+	"github.com/kruc/clockify-to-jira/internal/review"
+	"github.com/kruc/clockify-to-jira/internal/worklog"
+)
+
+func Test_reviewPlans(t *testing.T) {
+	client := &config.Client{}
+	newPlans := func() []*workspacePlan {
+		return []*workspacePlan{
+			{workspaceKey: "ws_1", worklogs: []worklog.Worklog{{IssueID: "ABC-1", Client: client}, {IssueID: "ABC-2", Client: client}}},
+			{workspaceKey: "ws_2", worklogs: []worklog.Worklog{{IssueID: "XYZ-1", Client: client}}, ignoredWorklogs: []worklog.Worklog{{IssueID: "XYZ-2", Client: client}}},
+		}
+	}
+	m := &migration{log: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	issueIDs := func(worklogs []worklog.Worklog) string {
+		ids := []string{}
+
+		for _, plannedWorklog := range worklogs {
+			ids = append(ids, plannedWorklog.IssueID)
+		}
+
+		return strings.Join(ids, ",")
+	}
+
+	t.Run("Keep accepted worklogs and move tagged ones to ignored", func(t *testing.T) {
+		plans := newPlans()
+		reviewer := review.New(strings.NewReader("s\ni\nABC-3\na\nt\ny\n"), &bytes.Buffer{}, time.UTC)
+
+		confirmed, err := m.reviewPlans(plans, reviewer)
+
+		if err != nil || !confirmed {
+			t.Fatalf("reviewPlans() = %v, %v, want true, nil", confirmed, err)
+		}
+
+		got := []string{issueIDs(plans[0].worklogs), issueIDs(plans[0].ignoredWorklogs), issueIDs(plans[1].worklogs), issueIDs(plans[1].ignoredWorklogs)}
+		want := []string{"ABC-3", "", "", "XYZ-2,XYZ-1"}
+
+		for index := range want {
+			if got[index] != want[index] {
+				t.Errorf("reviewPlans() = %q, want %q", got, want)
+				break
+			}
+		}
+	})
+
+	t.Run("Keep plans when not confirmed", func(t *testing.T) {
+		plans := newPlans()
+		reviewer := review.New(strings.NewReader("s\ns\na\nn\n"), &bytes.Buffer{}, time.UTC)
+
+		confirmed, err := m.reviewPlans(plans, reviewer)
+
+		if err != nil || confirmed {
+			t.Fatalf("reviewPlans() = %v, %v, want false, nil", confirmed, err)
+		}
+
+		if issueIDs(plans[0].worklogs) != "ABC-1,ABC-2" {
+			t.Errorf("reviewPlans() changed worklogs to %q", issueIDs(plans[0].worklogs))
+		}
+	})
+
+	t.Run("Check edited duration against caps", func(t *testing.T) {
+		capClient := &config.Client{DailyCap: 120, CapAction: worklog.CapActionBlock}
+		started := time.Date(2025, time.January, 13, 10, 0, 0, 0, time.Local)
+		plans := []*workspacePlan{
+			{
+				workspaceKey:   "ws_1",
+				worklogs:       []worklog.Worklog{{IssueID: "ABC-1", Client: capClient, Started: started, RoundedSeconds: 1800}},
+				loggedWorklogs: []worklog.Worklog{{IssueID: "ABC-2", Client: capClient, Started: started, RoundedSeconds: 3600}},
+			},
+		}
+		output := &bytes.Buffer{}
+		reviewer := review.New(strings.NewReader("d\n90\na\n"), output, time.UTC)
+
+		confirmed, err := m.reviewPlans(plans, reviewer)
+
+		if err != nil || confirmed {
+			t.Fatalf("reviewPlans() = %v, %v, want false, nil", confirmed, err)
+		}
+
+		if !strings.Contains(output.String(), "Nothing to apply") {
+			t.Errorf("reviewPlans() confirmation = %q, want nothing to apply", output.String())
+		}
+	})
+}
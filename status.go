@@ -0,0 +1,81 @@
+package main
+
+import (
+	"slices"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/clockify"
+	"github.com/kruc/clockify-to-jira/internal/config"
+	"github.com/kruc/clockify-to-jira/internal/report"
+)
+
+const (
+	unknownClient = "(unknown)"
+)
+
+func (m *migration) getStatusReport(workspaces config.Workspaces) string {
+
+	now := time.Now()
+	start, end := m.config.GetTimeInterval(&now)
+	statusData := report.NewStatusData(start, end)
+
+	for _, workspaceKey := range sortedWorkspaceKeys(workspaces) {
+		workspace := workspaces[workspaceKey]
+
+		timeEntries, err := m.clockifyClient.GetTimeEntriesFromGivenPeriod(start, end, workspace.WorkspaceId)
+
+		if err != nil {
+			m.log.Error("Ops, something went wrong during time entries fetching!",
+				"error", err,
+				"workspace", workspaceKey)
+			m.result.WorkspaceFailed(workspaceKey, err)
+			continue
+		}
+
+		for _, timeEntry := range timeEntries {
+			clientId, _, _, err := workspace.GetClient(timeEntry.ClientID, timeEntry.ClientName)
+
+			if err != nil {
+				clientId = unknownClient
+			}
+
+			if len(m.flag.Clients) != 0 && !slices.Contains(m.flag.Clients, clientId) {
+				continue
+			}
+
+			status, seconds := getTimeEntryStatus(workspace, timeEntry)
+			statusData.Add(workspaceKey, clientId, status, seconds)
+		}
+	}
+
+	return statusData.GetReport()
+}
+
+func getTimeEntryStatus(workspace *config.Workspace, timeEntry clockify.TimeEntry) (string, int) {
+
+	switch {
+	case timeEntry.Duration == "" || timeEntry.End == nil:
+		return report.StatusRunning, 0
+	case timeEntry.IsTaggedWith(workspace.JiraMigrationSuccessTag):
+		return report.StatusLogged, getTimeDiff(timeEntry.Start, *timeEntry.End)
+	case timeEntry.IsTaggedWith(workspace.JiraMigrationSkipTag):
+		return report.StatusSkipped, getTimeDiff(timeEntry.Start, *timeEntry.End)
+	case timeEntry.IsTaggedWith(workspace.JiraMigrationFailedTag):
+		return report.StatusFailed, getTimeDiff(timeEntry.Start, *timeEntry.End)
+	default:
+		return report.StatusPending, getTimeDiff(timeEntry.Start, *timeEntry.End)
+	}
+}
+
+func sortedWorkspaceKeys(workspaces config.Workspaces) []string {
+
+	keys := make([]string, 0, len(workspaces))
+
+	for key := range workspaces {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
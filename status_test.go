@@ -0,0 +1,67 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kruc/clockify-to-jira/internal/clockify"
+	"github.com/kruc/clockify-to-jira/internal/config"
+	"github.com/kruc/clockify-to-jira/internal/report"
+)
+
+func Test_getTimeEntryStatus(t *testing.T) {
+	workspace := &config.Workspace{
+		JiraMigrationFailedTag:  "failed",
+		JiraMigrationSkipTag:    "skipped",
+		JiraMigrationSuccessTag: "logged",
+	}
+	start := time.Date(2025, time.January, 6, 10, 0, 0, 0, time.Local)
+	end := start.Add(30 * time.Minute)
+
+	tests := []struct {
+		name        string
+		timeEntry   clockify.TimeEntry
+		wantStatus  string
+		wantSeconds int
+	}{
+		{
+			name:        "Pending time entry",
+			timeEntry:   clockify.TimeEntry{Start: start, End: &end, Duration: "PT30M"},
+			wantStatus:  report.StatusPending,
+			wantSeconds: 1800,
+		},
+		{
+			name:        "Failed time entry",
+			timeEntry:   clockify.TimeEntry{Start: start, End: &end, Duration: "PT30M", Tags: map[string]clockify.Tag{"failed": {}}},
+			wantStatus:  report.StatusFailed,
+			wantSeconds: 1800,
+		},
+		{
+			name:        "Logged time entry tagged as failed before",
+			timeEntry:   clockify.TimeEntry{Start: start, End: &end, Duration: "PT30M", Tags: map[string]clockify.Tag{"failed": {}, "logged": {}}},
+			wantStatus:  report.StatusLogged,
+			wantSeconds: 1800,
+		},
+		{
+			name:        "Skipped time entry",
+			timeEntry:   clockify.TimeEntry{Start: start, End: &end, Duration: "PT30M", Tags: map[string]clockify.Tag{"skipped": {}}},
+			wantStatus:  report.StatusSkipped,
+			wantSeconds: 1800,
+		},
+		{
+			name:        "Running time entry",
+			timeEntry:   clockify.TimeEntry{Start: start},
+			wantStatus:  report.StatusRunning,
+			wantSeconds: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, seconds := getTimeEntryStatus(workspace, tt.timeEntry)
+
+			if status != tt.wantStatus || seconds != tt.wantSeconds {
+				t.Errorf("getTimeEntryStatus() = %v, %v, want %v, %v", status, seconds, tt.wantStatus, tt.wantSeconds)
+			}
+		})
+	}
+}